@@ -0,0 +1,85 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v6/plumbing"
+
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+// Branches returns the repository's local and remote branches whose names
+// match the glob pattern, in name order, as commits with no message named
+// after the branch. Remote branches are named after their remote, as in
+// "origin/main", and "*" matches "/" like in `git branch --list`. Without a
+// pattern, only the current branch is returned, if HEAD is not detached.
+func Branches(repository repo.Repo, pattern string) Commits {
+	return func(yield func(*Commit) bool) {
+		r := repository()
+
+		if pattern == "" {
+			head, err := r.Reference(plumbing.HEAD, false)
+			if err != nil {
+				panic(err)
+			}
+
+			if head.Type() == plumbing.SymbolicReference && head.Target().IsBranch() {
+				yield(&Commit{Branch: head.Target().Short()})
+			}
+
+			return
+		}
+
+		re := globOf(pattern)
+
+		refs, err := r.References()
+		if err != nil {
+			panic(err)
+		}
+
+		names := make([]string, 0)
+
+		err = refs.ForEach(func(ref *plumbing.Reference) error {
+			if ref.Type() == plumbing.HashReference && (ref.Name().IsBranch() || ref.Name().IsRemote()) &&
+				re.MatchString(ref.Name().Short()) {
+				names = append(names, ref.Name().Short())
+			}
+
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		slices.Sort(names)
+
+		for _, n := range names {
+			if !yield(&Commit{Branch: n}) {
+				return
+			}
+		}
+	}
+}
+
+// globOf returns the regular expression of the glob pattern, where "*"
+// matches any characters, "/" included, and "?" matches any one character.
+func globOf(pattern string) *regexp.Regexp {
+	return regexp.MustCompile(`^` + strings.ReplaceAll(strings.ReplaceAll(
+		regexp.QuoteMeta(pattern), `\*`, `.*`), `\?`, `.`) + `$`)
+}
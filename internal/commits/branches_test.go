@@ -0,0 +1,93 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits_test
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+func TestBranches(t *testing.T) {
+	r := branchedRepo(t)
+
+	for _, test := range []struct {
+		pattern  string
+		expected []string
+	}{
+		{"", []string{"master"}},
+		{"*", []string{"feat/ABC-1-login", "fix-typo", "master", "origin/feat/XYZ-2-signup"}},
+		{"feat/*", []string{"feat/ABC-1-login"}},
+		{"*/feat/*", []string{"origin/feat/XYZ-2-signup"}},
+		{"fix-????", []string{"fix-typo"}},
+	} {
+		names := make([]string, 0)
+
+		for c := range commits.Branches(r, test.pattern) {
+			names = append(names, c.ShortID())
+		}
+
+		assert.Equal(t, test.expected, names,
+			"commits.Branches() must return the branches matching %q", test.pattern)
+	}
+}
+
+func TestBranchesDetached(t *testing.T) {
+	dir := tmpRepoDir(t, "commit")
+
+	r, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+
+	head, err := r.Head()
+	require.NoError(t, err)
+	require.NoError(t, r.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, head.Hash())))
+
+	assert.Empty(t, collected(commits.Branches(repo.Filesystem(dir), "")),
+		"commits.Branches() must return no current branch if HEAD is detached")
+}
+
+// branchedRepo returns a repo on master with local branches feat/ABC-1-login
+// and fix-typo, and remote branches origin/feat/XYZ-2-signup and origin/HEAD.
+func branchedRepo(t *testing.T) repo.Repo {
+	dir := tmpRepoDir(t, "commit")
+
+	r, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+
+	head, err := r.Head()
+	require.NoError(t, err)
+
+	for _, name := range []string{
+		"refs/heads/feat/ABC-1-login", "refs/heads/fix-typo", "refs/remotes/origin/feat/XYZ-2-signup",
+	} {
+		require.NoError(t, r.Storer.SetReference(
+			plumbing.NewHashReference(plumbing.ReferenceName(name), head.Hash()),
+		))
+	}
+
+	require.NoError(t, r.Storer.SetReference(
+		plumbing.NewSymbolicReference(
+			"refs/remotes/origin/HEAD", "refs/remotes/origin/feat/XYZ-2-signup",
+		),
+	))
+
+	return repo.Filesystem(dir)
+}
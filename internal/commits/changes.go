@@ -0,0 +1,249 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/filemode"
+	"github.com/go-git/go-git/v6/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v6/plumbing/object"
+)
+
+// Changes are the files changed by a commit relative to its first parent, or
+// all of its files if it is a root commit. They are computed the first time
+// they are needed.
+type Changes func() []*Change
+
+// Change is a file added, modified or deleted by a commit.
+type Change struct {
+	// Path is the file's path relative to the root of the repo.
+	Path    string
+	Deleted bool
+	// File describes the file as of the commit, looking it up the first time
+	// it is called. Nil if the file was deleted.
+	File func() *File
+}
+
+// File describes the contents of a file. Submodules are empty files.
+type File struct {
+	Size   int64
+	Binary bool
+}
+
+// Stats are the numbers of lines added and deleted in each file changed by a
+// commit relative to its first parent, like `git diff --numstat`. They are
+// computed the first time they are needed, which is costlier than Changes
+// since the contents of the files must be compared.
+type Stats func() []*Stat
+
+// Stat is the number of lines added and deleted in a file. Binary files have
+// none.
+type Stat struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+// Pathspec tells whether a file path is selected.
+type Pathspec func(path string) bool
+
+// PathsOf returns the pathspec selecting the paths that match any of the
+// patterns. Patterns follow .gitignore syntax, so "docs" and "docs/**" both
+// match every file under the docs directory. Blank patterns are ignored.
+func PathsOf(patterns []string) Pathspec {
+	spec := pathspec(patterns)
+
+	return func(path string) bool {
+		return matches(spec, path)
+	}
+}
+
+// Touches tells whether the commit changes any file selected by the pathspec.
+// Commits that are not in a repository touch nothing.
+func (c *Commit) Touches(paths Pathspec) bool {
+	if c.Changes == nil {
+		return false
+	}
+
+	for _, change := range c.Changes() {
+		if paths(change.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Touching returns the commits that change files matching any of the
+// patterns given to PathsOf, like `git log -- <path>...`.
+// If there are no patterns, all commits are returned.
+func Touching(patterns []string, cmts Commits) Commits {
+	if len(pathspec(patterns)) == 0 {
+		return cmts
+	}
+
+	paths := PathsOf(patterns)
+
+	return filtered(
+		func(c *Commit) bool {
+			return c.Touches(paths)
+		},
+		cmts,
+	)
+}
+
+// diffOf compares the commit with its first parent the first time it's needed.
+// The diff is empty if the parent is missing from a shallow clone.
+func diffOf(c *object.Commit, storage *sync.Mutex) func() object.Changes {
+	return sync.OnceValue(func() object.Changes {
+		storage.Lock()
+		defer storage.Unlock()
+
+		to, err := c.Tree()
+		if err != nil {
+			panic(err)
+		}
+
+		from, found := firstParentTree(c)
+		if !found {
+			return object.Changes{}
+		}
+
+		diff, err := object.DiffTree(from, to)
+		if err != nil {
+			panic(err)
+		}
+
+		return diff
+	})
+}
+
+func changesOf(diff func() object.Changes, storage *sync.Mutex) Changes {
+	return sync.OnceValue(func() []*Change {
+		changes := make([]*Change, 0, len(diff()))
+
+		for _, d := range diff() {
+			change := &Change{Path: d.To.Name}
+
+			if change.Path == "" {
+				change.Path = d.From.Name
+				change.Deleted = true
+			} else {
+				change.File = fileOf(d.To, storage)
+			}
+
+			changes = append(changes, change)
+		}
+
+		return changes
+	})
+}
+
+func statsOf(diff func() object.Changes, storage *sync.Mutex) Stats {
+	return sync.OnceValue(func() []*Stat {
+		changes := diff()
+
+		storage.Lock()
+		defer storage.Unlock()
+
+		patch, err := changes.Patch()
+		if err != nil {
+			panic(err)
+		}
+
+		stats := make([]*Stat, 0, len(changes))
+
+		for _, s := range patch.Stats() {
+			stats = append(stats, &Stat{Path: s.Name, Additions: s.Addition, Deletions: s.Deletion})
+		}
+
+		return stats
+	})
+}
+
+func fileOf(entry object.ChangeEntry, storage *sync.Mutex) func() *File {
+	return sync.OnceValue(func() *File {
+		if entry.TreeEntry.Mode == filemode.Submodule {
+			return &File{}
+		}
+
+		storage.Lock()
+		defer storage.Unlock()
+
+		f, err := entry.Tree.TreeEntryFile(&entry.TreeEntry)
+		if err != nil {
+			panic(err)
+		}
+
+		binary, err := f.IsBinary()
+		if err != nil {
+			panic(err)
+		}
+
+		return &File{Size: f.Size, Binary: binary}
+	})
+}
+
+// firstParentTree returns a nil tree for root commits, which diffs as empty.
+// It is not found if the parent is missing from a shallow clone.
+func firstParentTree(c *object.Commit) (tree *object.Tree, found bool) {
+	if len(c.ParentHashes) == 0 {
+		return nil, true
+	}
+
+	parent, err := c.Parent(0)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, false
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	tree, err = parent.Tree()
+	if err != nil {
+		panic(err)
+	}
+
+	return tree, true
+}
+
+func pathspec(patterns []string) []gitignore.Pattern {
+	spec := make([]gitignore.Pattern, 0, len(patterns))
+
+	for _, p := range patterns {
+		if p = strings.TrimSpace(p); p != "" {
+			spec = append(spec, gitignore.ParsePattern(p, nil))
+		}
+	}
+
+	return spec
+}
+
+func matches(spec []gitignore.Pattern, path string) bool {
+	parts := strings.Split(path, "/")
+
+	for _, p := range spec {
+		if p.Match(parts, false) == gitignore.Exclude {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+func TestChanges(t *testing.T) {
+	cmts := collected(commits.In(
+		tmpRepoOfPaths(t, "README.md", "services/billing/main.go", "-README.md"),
+	))
+	require.Len(t, cmts, 3)
+	assert.Equal(t,
+		[][]string{{"README.md"}, {"services/billing/main.go"}, {"README.md"}},
+		[][]string{paths(cmts[2]), paths(cmts[1]), paths(cmts[0])},
+		"commits.Commit.Changes must list the files changed relative to the first parent",
+	)
+}
+
+func TestChangesFiles(t *testing.T) {
+	cmts := collected(commits.In(tmpRepoOfPaths(t, "README.md", "-README.md")))
+	require.Len(t, cmts, 2)
+
+	added := cmts[1].Changes()[0]
+	assert.Equal(t,
+		&commits.File{Size: int64(len("README.md"))},
+		added.File(),
+		"commits.Change.File must describe the file's contents",
+	)
+
+	deleted := cmts[0].Changes()[0]
+	assert.True(t, deleted.Deleted, "commits.Change.Deleted must be set for deleted files")
+	assert.Nil(t, deleted.File, "commits.Change.File must be nil for deleted files")
+}
+
+func TestStats(t *testing.T) {
+	cmts := collected(commits.In(tmpRepoOfPaths(t, "README.md", "-README.md")))
+	require.Len(t, cmts, 2)
+	assert.Equal(t,
+		[]*commits.Stat{{Path: "README.md", Additions: 1}},
+		cmts[1].Stats(),
+		"commits.Commit.Stats must count the lines added relative to the first parent",
+	)
+	assert.Equal(t,
+		[]*commits.Stat{{Path: "README.md", Deletions: 1}},
+		cmts[0].Stats(),
+		"commits.Commit.Stats must count the lines deleted relative to the first parent",
+	)
+}
+
+func TestTouching(t *testing.T) {
+	r := tmpRepoOfPaths(t,
+		"README.md", "services/billing/main.go", "services/users/main.go", "docs/billing.md",
+	)
+
+	for _, test := range []struct {
+		patterns []string
+		expected []string
+	}{
+		{[]string{"services/billing/**"}, []string{"services/billing/main.go"}},
+		{[]string{"services"}, []string{"services/users/main.go", "services/billing/main.go"}},
+		{[]string{"*.md"}, []string{"docs/billing.md", "README.md"}},
+		{[]string{"/README.md", "services/users/**"}, []string{"services/users/main.go", "README.md"}},
+		{[]string{""}, []string{
+			"docs/billing.md", "services/users/main.go", "services/billing/main.go", "README.md",
+		}},
+	} {
+		touching := make([]string, 0)
+
+		for _, c := range collected(commits.Touching(test.patterns, commits.In(r))) {
+			touching = append(touching, c.Subject())
+		}
+
+		assert.Equal(t, test.expected, touching,
+			"commits.Touching() must only return the commits changing matching files: %v", test.patterns,
+		)
+	}
+}
+
+func TestPathsOf(t *testing.T) {
+	paths := commits.PathsOf([]string{"frontend/**", " *.pem", ""})
+
+	assert.True(t, paths("frontend/src/app.ts"),
+		"commits.PathsOf() must match paths under a directory pattern")
+	assert.True(t, paths("infra/certs/key.pem"),
+		"commits.PathsOf() must match file names at any depth")
+	assert.False(t, paths("infra/main.tf"), "commits.PathsOf() must not match other paths")
+}
+
+func TestTouchingMsg(t *testing.T) {
+	assert.Empty(t,
+		collected(commits.Touching([]string{"**"}, stream(&commits.Commit{Hash: "fakehsh"}))),
+		"commits.Touching() must not return commits without changes",
+	)
+}
+
+func TestChangesConcurrently(t *testing.T) {
+	r := packedRepo(t, 40)
+
+	var (
+		wg    sync.WaitGroup
+		lines = make([]int, 0)
+		mu    sync.Mutex
+	)
+
+	// the lazy fields are read while the walk goes on, as the workers of
+	// issues.CollectedConcurrently do; run with -race
+	for c := range commits.In(r) {
+		wg.Go(func() {
+			n := 0
+
+			for _, change := range c.Changes() {
+				if change.File != nil {
+					change.File()
+				}
+			}
+
+			for _, s := range c.Stats() {
+				n += s.Additions
+			}
+
+			c.ParentDate()
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			lines = append(lines, n)
+		})
+	}
+
+	wg.Wait()
+
+	total := 0
+	for _, n := range lines {
+		total += n
+	}
+
+	assert.Len(t, lines, 40)
+	assert.Equal(t, 2*40, total,
+		"commits.Commit's lazy fields must be safe to read while walking packed repositories")
+}
+
+// packedRepo returns a repo with this many commits, each adding a line to
+// two files, with all of its objects in a packfile as after `git gc`.
+func packedRepo(t *testing.T, commitCount int) repo.Repo {
+	folder := t.TempDir()
+
+	r, err := git.PlainInit(folder, false)
+	require.NoError(t, err)
+
+	wt, err := r.Worktree()
+	require.NoError(t, err)
+
+	contents := make([]string, 0)
+
+	for i := range commitCount {
+		contents = append(contents, strings.Repeat(fmt.Sprintf("line %d ", i), 200))
+
+		for _, file := range []string{"a.txt", "b.txt"} {
+			require.NoError(t, os.WriteFile(
+				filepath.Join(folder, file), []byte(strings.Join(contents, "\n")+"\n"), 0o600,
+			))
+
+			_, err = wt.Add(file)
+			require.NoError(t, err)
+		}
+
+		_, err = wt.Commit(fmt.Sprintf("commit %d", i), &git.CommitOptions{Author: testSignature()})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, r.RepackObjects(&git.RepackConfig{}))
+
+	loose, err := filepath.Glob(filepath.Join(folder, ".git", "objects", "[0-9a-f][0-9a-f]"))
+	require.NoError(t, err)
+
+	for _, dir := range loose {
+		require.NoError(t, os.RemoveAll(dir))
+	}
+
+	return repo.Filesystem(folder)
+}
+
+// tmpRepoOfPaths commits each path in turn, with the path as the message.
+// Paths prefixed with "-" are deleted instead.
+func tmpRepoOfPaths(t *testing.T, paths ...string) repo.Repo {
+	folder := t.TempDir()
+
+	r, err := git.PlainInit(folder, false)
+	require.NoError(t, err)
+
+	wt, err := r.Worktree()
+	require.NoError(t, err)
+
+	for _, p := range paths {
+		if p[0] == '-' {
+			p = p[1:]
+			_, err = wt.Remove(p)
+			require.NoError(t, err)
+		} else {
+			require.NoError(t, os.MkdirAll(filepath.Join(folder, filepath.Dir(p)), 0o700))
+			require.NoError(t, os.WriteFile(filepath.Join(folder, p), []byte(p), 0o600))
+
+			_, err = wt.Add(p)
+			require.NoError(t, err)
+		}
+
+		_, err = wt.Commit(p, &git.CommitOptions{Author: testSignature()})
+		require.NoError(t, err)
+	}
+
+	return repo.Filesystem(folder)
+}
+
+func paths(c *commits.Commit) []string {
+	p := make([]string, 0)
+
+	for _, change := range c.Changes() {
+		p = append(p, change.Path)
+	}
+
+	return p
+}
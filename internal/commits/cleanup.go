@@ -0,0 +1,126 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// scissors is the line below which `git commit -v` shows the diff being
+// committed, after the comment string.
+const scissors = " ------------------------ >8 ------------------------"
+
+// CleanedUp returns the commits with their messages cleaned up like git does
+// before committing them, given git's commit.cleanup mode and the comment
+// string set in core.commentChar:
+//   - verbatim leaves messages as they are
+//   - whitespace strips trailing whitespace, and leading, trailing and
+//     consecutive empty lines
+//   - scissors also drops everything from the scissors line down
+//   - strip also drops comment lines, and the scissors line and everything
+//     below it, as git does with `git commit -v`
+//
+// The default mode, as well as an empty one, is strip as when git runs an
+// editor. An empty or "auto" comment string is "#".
+// It fails if the mode is unknown.
+func CleanedUp(mode, comment string, cmts Commits) (Commits, error) {
+	if comment == "" || comment == "auto" {
+		comment = "#"
+	}
+
+	var cleanup func(string) string
+
+	switch mode {
+	case "verbatim":
+		cleanup = func(msg string) string { return msg }
+	case "whitespace":
+		cleanup = stripSpace
+	case "scissors":
+		cleanup = func(msg string) string { return stripSpace(cut(msg, comment)) }
+	case "strip", "default", "":
+		cleanup = func(msg string) string { return stripSpace(uncommented(cut(msg, comment), comment)) }
+	default:
+		return nil, fmt.Errorf(
+			"invalid cleanup mode [%s]: expected strip, whitespace, scissors or verbatim", mode,
+		)
+	}
+
+	return func(yield func(*Commit) bool) {
+		for c := range cmts {
+			cleaned := *c
+			cleaned.Message = cleanup(c.Message)
+
+			if !yield(&cleaned) {
+				return
+			}
+		}
+	}, nil
+}
+
+// cut drops the scissors line and everything below it.
+func cut(msg, comment string) string {
+	re := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(comment+scissors) + `$`)
+
+	if loc := re.FindStringIndex(msg); loc != nil {
+		return msg[:loc[0]]
+	}
+
+	return msg
+}
+
+func uncommented(msg, comment string) string {
+	lines := strings.Split(msg, "\n")
+	kept := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, comment) {
+			kept = append(kept, line)
+		}
+	}
+
+	return strings.Join(kept, "\n")
+}
+
+// stripSpace is git's stripspace: it strips trailing whitespace from every
+// line, collapses consecutive empty lines and drops leading and trailing
+// ones, and ends non-empty messages with a newline.
+func stripSpace(msg string) string {
+	var b strings.Builder
+
+	empty := 0
+
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimRight(line, " \t\r\v\f")
+
+		if line == "" {
+			empty++
+
+			continue
+		}
+
+		if empty > 0 && b.Len() > 0 {
+			b.WriteString("\n")
+		}
+
+		empty = 0
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
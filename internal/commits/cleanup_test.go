@@ -0,0 +1,59 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+func TestCleanedUp(t *testing.T) {
+	const msg = "\n\nsubject  \n\n\n# comment\nbody\t\n; not a comment\n" +
+		"# ------------------------ >8 ------------------------\n" +
+		"diff --git a/file b/file\n"
+
+	for _, test := range []struct {
+		mode     string
+		comment  string
+		expected string
+	}{
+		{"verbatim", "", msg},
+		{"whitespace", "", "subject\n\n# comment\nbody\n; not a comment\n" +
+			"# ------------------------ >8 ------------------------\ndiff --git a/file b/file\n"},
+		{"scissors", "", "subject\n\n# comment\nbody\n; not a comment\n"},
+		{"strip", "", "subject\n\nbody\n; not a comment\n"},
+		{"", "auto", "subject\n\nbody\n; not a comment\n"},
+		{"strip", ";", "subject\n\n# comment\nbody\n" +
+			"# ------------------------ >8 ------------------------\ndiff --git a/file b/file\n"},
+	} {
+		cleaned, err := commits.CleanedUp(
+			test.mode, test.comment, stream(&commits.Commit{Message: msg}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, test.expected, collected(cleaned)[0].Message,
+			"commits.CleanedUp() must clean up messages like git's %q mode with comment %q",
+			test.mode, test.comment)
+	}
+}
+
+func TestCleanedUpInvalidMode(t *testing.T) {
+	_, err := commits.CleanedUp("bogus", "#", stream())
+	assert.Error(t, err, "commits.CleanedUp() must fail on unknown modes")
+}
@@ -16,37 +16,69 @@
 package commits
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"iter"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/llorllale/go-gitlint/internal/repo"
 	git "github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
 	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/go-git/go-git/v6/plumbing/storer"
 )
 
-// Commits returns commits.
+// Commits is a stream of commits, fetched lazily as they are iterated.
 // @todo #4 Figure out how to disable the golint check that
 //  forces us to write redundant comments of the form
 //  'comment on exported type Commits should be of the form
 //  "Commits ..." (with optional leading article)' and rewrite
 //  all comments.
-type Commits func() []*Commit
+type Commits iter.Seq[*Commit]
 
 // Commit holds data for a single git commit.
 type Commit struct {
-	Hash       string
-	Message    string
+	Hash    string
+	Message string
+	// Date is the author date.
 	Date       time.Time
+	CommitDate time.Time
 	NumParents int
+	// ParentDate is the latest commit date among the commit's parents. It is
+	// nil if the commit is not in a repository.
+	ParentDate ParentDate
 	Author     *Author
+	Committer  *Author
+	// Signature is the commit's armored PGP or SSH signature, if any.
+	Signature string
+	// Payload is the encoded commit that was signed. Only set on signed commits.
+	Payload string
+	// Changes are the files changed by the commit and Stats the lines changed
+	// in them. Both are nil if the commit is not in a repository.
+	Changes Changes
+	Stats   Stats
+	// Tag is the name of the annotated tag linted in place of a commit, whose
+	// message, date and tagger stand for the commit's message, date and author.
+	Tag string
+	// Branch is the name of the branch linted in place of a commit.
+	Branch string
 }
 
-// Author is the author of a commit.
+// ParentDate returns the latest commit date among a commit's parents, which
+// are only loaded the first time it is called. It is zero for root commits.
+type ParentDate func() time.Time
+
+// Author is the author or committer of a commit.
 type Author struct {
 	Name  string
 	Email string
+	// Canonical is set by Mailmapped if this identity is known to the mailmap.
+	Canonical bool
 }
 
 // ID is the commit's hash.
@@ -54,8 +86,22 @@ func (c *Commit) ID() string {
 	return c.Hash
 }
 
-// ShortID returns the commit hash's short form.
+// ShortID returns the commit hash's short form. Tags and branches are
+// identified by their name instead, and messages read from files by the
+// file's name.
 func (c *Commit) ShortID() string {
+	if c.Tag != "" {
+		return c.Tag
+	}
+
+	if c.Branch != "" {
+		return c.Branch
+	}
+
+	if !plumbing.IsHash(c.Hash) {
+		return c.Hash
+	}
+
 	return c.Hash[:7]
 }
 
@@ -76,12 +122,61 @@ func (c *Commit) Body() string {
 	return body
 }
 
+// Trailer is a "Key: value" line at the end of a commit message, like
+// "Signed-off-by: John Doe <john@doe.org>".
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// Trailers are the trailers found in the last paragraph of the commit
+// message's body, in order. Like git-interpret-trailers, lines starting with
+// whitespace continue the previous trailer's value. The paragraph holds no
+// trailers unless all of its lines are trailers or continuations.
+func (c *Commit) Trailers() []*Trailer {
+	trailers := make([]*Trailer, 0)
+	paragraphs := strings.Split(strings.TrimRight(c.Message, " \t\n"), "\n\n")
+
+	if len(paragraphs) < 2 {
+		return trailers
+	}
+
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		if strings.TrimLeft(line, " \t") != line && len(trailers) > 0 {
+			last := trailers[len(trailers)-1]
+			last.Value += " " + strings.TrimSpace(line)
+
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found || !isTrailerKey(key) {
+			return []*Trailer{}
+		}
+
+		trailers = append(trailers, &Trailer{Key: key, Value: strings.TrimSpace(value)})
+	}
+
+	return trailers
+}
+
+// Stop tells a walk through a repository's history when to stop.
+// n is the number of commits walked before c.
+type Stop func(n int, c *Commit) bool
+
 // In returns the commits in the repo.
+func In(repository repo.Repo) Commits {
+	return Walked(repository)
+}
+
+// Walked returns the commits in the repo, walking history back from HEAD in
+// commit date order (newest first) and stopping at the first commit for
+// which any of the stops is true.
 // @todo #4 These err checks are extremely annoying. Figure out
 //  how to handle them elegantly and reduce the cyclo complexity
 //  of this function (currently at 4).
-func In(repository repo.Repo) Commits {
-	return func() []*Commit {
+func Walked(repository repo.Repo, stops ...Stop) Commits {
+	return func(yield func(*Commit) bool) {
 		r := repository()
 
 		ref, err := r.Head()
@@ -89,89 +184,191 @@ func In(repository repo.Repo) Commits {
 			panic(err)
 		}
 
-		iter, err := r.Log(&git.LogOptions{From: ref.Hash()})
+		log, err := r.Log(&git.LogOptions{From: ref.Hash(), Order: git.LogOrderCommitterTime})
 		if err != nil {
 			panic(err)
 		}
 
-		commits := make([]*Commit, 0)
+		walked := 0
+		storage := &sync.Mutex{}
+
+		err = forEach(storage, log, func(c *object.Commit) error {
+			commit := commitOf(c, storage)
+
+			for _, stop := range stops {
+				if stop(walked, commit) {
+					return storer.ErrStop
+				}
+			}
 
-		err = iter.ForEach(func(c *object.Commit) error {
-			commits = append(
-				commits,
-				&Commit{
-					Hash:       c.Hash.String(),
-					Message:    c.Message,
-					Date:       c.Author.When,
-					NumParents: len(c.ParentHashes),
-					Author: &Author{
-						Name:  c.Author.Name,
-						Email: c.Author.Email,
-					},
-				},
-			)
+			walked++
+
+			if !yield(commit) {
+				return storer.ErrStop
+			}
 
 			return nil
 		})
 		if err != nil {
 			panic(err)
 		}
+	}
+}
 
-		return commits
+// AtMost stops a walk after n commits. A negative n never stops it.
+func AtMost(n int) Stop {
+	return func(walked int, _ *Commit) bool {
+		return n >= 0 && walked >= n
 	}
 }
 
-// Since returns commits authored since time t (format: yyyy-MM-dd).
-func Since(t string, cmts Commits) Commits {
+// CommittedBefore stops a walk at the first commit with a commit date before
+// time t. Since walks go in commit date order, the commits left unwalked
+// were all committed before t too, unless committers' clocks were skewed.
+func CommittedBefore(t time.Time) Stop {
+	return func(_ int, c *Commit) bool {
+		return c.CommitDate.Before(t)
+	}
+}
+
+// forEach calls f with each of the commits like object.CommitIter.ForEach,
+// reading them from the repository's storage with the lock held. The lazy
+// fields of the commits read the same storage, which isn't safe for
+// concurrent use, so they take the lock too; f is called without it so that
+// they can be read while the walk goes on, like issues.CollectedConcurrently
+// does.
+func forEach(storage *sync.Mutex, commits object.CommitIter, f func(*object.Commit) error) error {
+	storage.Lock()
+	defer storage.Unlock()
+
+	return commits.ForEach(func(c *object.Commit) error {
+		storage.Unlock()
+		defer storage.Lock()
+
+		return f(c)
+	})
+}
+
+// commitOf returns the commit, whose lazy fields read from the storage with
+// its lock held.
+func commitOf(c *object.Commit, storage *sync.Mutex) *Commit {
+	diff := diffOf(c, storage)
+
+	return &Commit{
+		Hash:       c.Hash.String(),
+		Message:    c.Message,
+		Date:       c.Author.When,
+		CommitDate: c.Committer.When,
+		NumParents: len(c.ParentHashes),
+		ParentDate: sync.OnceValue(func() time.Time {
+			storage.Lock()
+			defer storage.Unlock()
+
+			return parentDate(c)
+		}),
+		Author: &Author{
+			Name:  c.Author.Name,
+			Email: c.Author.Email,
+		},
+		Committer: &Author{
+			Name:  c.Committer.Name,
+			Email: c.Committer.Email,
+		},
+		Signature: c.Signature,
+		Payload:   payload(c),
+		Changes:   changesOf(diff, storage),
+		Stats:     statsOf(diff, storage),
+	}
+}
+
+// Roots returns the commits reachable from HEAD that have no parents, none
+// if HEAD is unborn as in empty repositories. Finding them walks the whole
+// history, however far walks with stops would go.
+func Roots(repository repo.Repo) Commits {
+	return func(yield func(*Commit) bool) {
+		r := repository()
+
+		ref, err := r.Head()
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return
+		}
+
+		if err != nil {
+			panic(err)
+		}
+
+		log, err := r.Log(&git.LogOptions{From: ref.Hash()})
+		if err != nil {
+			panic(err)
+		}
+
+		storage := &sync.Mutex{}
+
+		err = forEach(storage, log, func(c *object.Commit) error {
+			if len(c.ParentHashes) == 0 && !yield(commitOf(c, storage)) {
+				return storer.ErrStop
+			}
+
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Since returns commits authored at or after time t.
+func Since(t time.Time, cmts Commits) Commits {
 	return filtered(
 		func(c *Commit) bool {
-			start, err := time.Parse("2006-01-02", t)
-			if err != nil {
-				panic(err)
-			}
-			return !c.Date.Before(start)
+			return !c.Date.Before(t)
 		},
 		cmts,
 	)
 }
 
-// NotAuthoredByNames filters out commits with authors whose names match any of the given patterns.
-func NotAuthoredByNames(patterns []string, cmts Commits) Commits {
+// Until returns commits authored at or before time t.
+// A zero t places no upper bound on the commits returned.
+func Until(t time.Time, cmts Commits) Commits {
 	return filtered(
 		func(c *Commit) bool {
-			for _, p := range patterns {
-				match, err := regexp.MatchString(p, c.Author.Name)
-				if err != nil {
-					panic(err)
-				}
-				if match {
-					return false
-				}
-			}
-			return true
+			return t.IsZero() || !c.Date.After(t)
 		},
 		cmts,
 	)
 }
 
+// NotAuthoredByNames filters out commits with authors whose names match any of the given patterns.
+// It fails if any of the patterns is not a valid regular expression.
+func NotAuthoredByNames(patterns []string, cmts Commits) (Commits, error) {
+	regexes, err := compiled(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return filtered(
+		func(c *Commit) bool {
+			return !matchesAny(regexes, c.Author.Name)
+		},
+		cmts,
+	), nil
+}
+
 // NotAuthoredByEmails filters out commits with authors whose emails match any
 // of the given patterns.
-func NotAuthoredByEmails(patterns []string, cmts Commits) Commits {
+// It fails if any of the patterns is not a valid regular expression.
+func NotAuthoredByEmails(patterns []string, cmts Commits) (Commits, error) {
+	regexes, err := compiled(patterns)
+	if err != nil {
+		return nil, err
+	}
+
 	return filtered(
 		func(c *Commit) bool {
-			for _, p := range patterns {
-				match, err := regexp.MatchString(p, c.Author.Email)
-				if err != nil {
-					panic(err)
-				}
-				if match {
-					return false
-				}
-			}
-			return true
+			return !matchesAny(regexes, c.Author.Email)
 		},
 		cmts,
-	)
+	), nil
 }
 
 // WithMaxParents returns commits that have at most n number of parents.
@@ -185,33 +382,148 @@ func WithMaxParents(n int, cmts Commits) Commits {
 	)
 }
 
-// MsgIn returns a single fake commit with the message read from this reader.
-// This fake commit will have a fake hash and its timestamp will be time.Now().
-func MsgIn(reader io.Reader) Commits {
-	return func() []*Commit {
-		b, err := io.ReadAll(reader)
+// MsgsIn returns a fake commit for each of the files matching the glob
+// patterns, with the message in the file and identified by the file's name.
+// The pattern "-" is the message read from stdin.
+// It fails if a pattern is invalid or matches no files.
+func MsgsIn(stdin io.Reader, patterns []string) (Commits, error) {
+	files, err := filesOf("message", patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(*Commit) bool) {
+		for _, f := range files {
+			name, b := read(stdin, f)
+
+			if !yield(&Commit{Hash: name, Message: string(b), Date: time.Now()}) {
+				return
+			}
+		}
+	}, nil
+}
+
+// filesOf returns the files matching the glob patterns, keeping "-".
+func filesOf(kind string, patterns []string) ([]string, error) {
+	files := make([]string, 0, len(patterns))
+	errs := make([]error, 0)
+
+	for _, p := range patterns {
+		if p == "-" {
+			files = append(files, p)
+
+			continue
+		}
+
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s file pattern [%s]: %w", kind, p, err))
+
+			continue
+		}
+
+		if len(matches) == 0 {
+			errs = append(errs, fmt.Errorf("no %s files match [%s]", kind, p))
+		}
+
+		files = append(files, matches...)
+	}
+
+	return files, errors.Join(errs...)
+}
+
+func payload(c *object.Commit) string {
+	if c.Signature == "" {
+		return ""
+	}
+
+	encoded := &plumbing.MemoryObject{}
+
+	if err := c.EncodeWithoutSignature(encoded); err != nil {
+		panic(err)
+	}
+
+	reader, err := encoded.Reader()
+	if err != nil {
+		panic(err)
+	}
+
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(b)
+}
+
+// parentDate skips parents missing from shallow clones.
+func parentDate(c *object.Commit) time.Time {
+	var latest time.Time
+
+	for i := range c.ParentHashes {
+		p, err := c.Parent(i)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			continue
+		}
+
 		if err != nil {
 			panic(err)
 		}
 
-		return []*Commit{{
-			Hash:    "fakehsh",
-			Message: string(b),
-			Date:    time.Now(),
-		}}
+		if p.Committer.When.After(latest) {
+			latest = p.Committer.When
+		}
 	}
+
+	return latest
 }
 
-func filtered(filter func(*Commit) bool, in Commits) (out Commits) {
-	return func() []*Commit {
-		f := make([]*Commit, 0)
+func isTrailerKey(key string) bool {
+	for i, r := range key {
+		alphanumeric := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+		if !alphanumeric && (i == 0 || r != '-') {
+			return false
+		}
+	}
 
-		for _, c := range in() {
-			if filter(c) {
-				f = append(f, c)
-			}
+	return key != ""
+}
+
+// compiled returns the errors of all invalid patterns together.
+func compiled(patterns []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, 0, len(patterns))
+	errs := make([]error, 0)
+
+	for _, p := range patterns {
+		r, err := regexp.Compile(p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid regex [%s]: %w", p, err))
+
+			continue
 		}
 
-		return f
+		regexes = append(regexes, r)
+	}
+
+	return regexes, errors.Join(errs...)
+}
+
+func matchesAny(regexes []*regexp.Regexp, s string) bool {
+	for _, r := range regexes {
+		if r.MatchString(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func filtered(filter func(*Commit) bool, in Commits) (out Commits) {
+	return func(yield func(*Commit) bool) {
+		for c := range in {
+			if filter(c) && !yield(c) {
+				return
+			}
+		}
 	}
 }
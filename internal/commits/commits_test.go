@@ -16,8 +16,10 @@ package commits_test
 
 import (
 	"fmt"
+	"iter"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -67,10 +69,38 @@ func TestCommitBody(t *testing.T) {
 		`Commit.Body() must return the substring after the first \n\n`)
 }
 
+func TestCommitTrailers(t *testing.T) {
+	assert.Equal(t,
+		[]*commits.Trailer{
+			{Key: "Change-Ticket", Value: "OPS-42"},
+			{Key: "Signed-off-by", Value: "John Doe <john@doe.org> on behalf of Jane"},
+		},
+		(&commits.Commit{
+			Message: "subject\n\nbody: not a trailer\n\n" +
+				"Change-Ticket: OPS-42\nSigned-off-by: John Doe <john@doe.org>\n  on behalf of Jane\n",
+		}).Trailers(),
+		"Commit.Trailers() must return the trailers in the last paragraph",
+	)
+}
+
+func TestCommitTrailersNone(t *testing.T) {
+	for _, msg := range []string{
+		"Change-Ticket: OPS-42",
+		"subject\n\nChange-Ticket: OPS-42\nthis line is not a trailer",
+		"subject\n\nnot a key: OPS-42",
+	} {
+		assert.Empty(t,
+			(&commits.Commit{Message: msg}).Trailers(),
+			"Commit.Trailers() must return nothing unless the body's last paragraph is all trailers: %q",
+			msg,
+		)
+	}
+}
+
 func TestIn(t *testing.T) {
 	msgs := []string{"subject1\n\nbody1", "subject2\n\nbody2", "subject3\n\nbody3"}
 	r := tmpRepo(t, msgs...)
-	cmits := commits.In(r)()
+	cmits := collected(commits.In(r))
 
 	assert.Len(t, cmits, len(msgs),
 		"commits.In() did not return the correct number of commits")
@@ -82,6 +112,59 @@ func TestIn(t *testing.T) {
 	}
 }
 
+func TestWalked(t *testing.T) {
+	r := tmpRepo(t, "first", "second", "third")
+
+	assert.Len(t, collected(commits.Walked(r)), 3,
+		"commits.Walked() must walk all commits if there are no stops")
+	assert.Len(t, collected(commits.Walked(r, commits.AtMost(2))), 2,
+		"commits.Walked() must stop walking when a stop is reached")
+	assert.Empty(t, collected(commits.Walked(r, commits.CommittedBefore(time.Now().Add(time.Hour)))),
+		"commits.Walked() must stop walking when a stop is reached")
+	assert.Len(t,
+		collected(commits.Walked(r, commits.AtMost(-1), commits.CommittedBefore(time.Unix(0, 0)))), 3,
+		"commits.Walked() must not stop walking if no stop is reached")
+}
+
+func TestAtMost(t *testing.T) {
+	assert.False(t, commits.AtMost(2)(1, &commits.Commit{}))
+	assert.True(t, commits.AtMost(2)(2, &commits.Commit{}))
+	assert.False(t, commits.AtMost(-1)(100, &commits.Commit{}))
+}
+
+func TestCommittedBefore(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t,
+		commits.CommittedBefore(now)(0, &commits.Commit{CommitDate: now.Add(-time.Second)}))
+	assert.False(t, commits.CommittedBefore(now)(0, &commits.Commit{CommitDate: now}))
+}
+
+func TestInDates(t *testing.T) {
+	cmits := collected(commits.In(tmpRepo(t, "first", "second")))
+
+	assert.Len(t, cmits, 2)
+	assert.False(t, cmits[0].CommitDate.IsZero(),
+		"commits.In() must set the commit date")
+	assert.Equal(t, cmits[1].CommitDate, cmits[0].ParentDate(),
+		"commits.In() must set the parent's commit date")
+	assert.Zero(t, cmits[1].ParentDate(),
+		"commits.In() must not set a parent date on root commits")
+}
+
+func TestRoots(t *testing.T) {
+	cmits := collected(commits.Roots(tmpRepo(t, "root", "child")))
+
+	require.Len(t, cmits, 1)
+	assert.Equal(t, "root", cmits[0].Message,
+		"commits.Roots() must return the commits without parents")
+}
+
+func TestRootsUnborn(t *testing.T) {
+	assert.Empty(t, collected(commits.Roots(tmpRepo(t))),
+		"commits.Roots() must return no commits if HEAD is unborn")
+}
+
 func TestSince(t *testing.T) {
 	before, err := time.Parse("2006-01-02", "2017-10-25")
 	require.NoError(t, err)
@@ -92,42 +175,82 @@ func TestSince(t *testing.T) {
 	after, err := time.Parse("2006-01-02", "2019-03-03")
 	require.NoError(t, err)
 
-	cmits := commits.Since(
-		"2019-01-01",
-		func() []*commits.Commit {
-			return []*commits.Commit{
-				{Date: before},
-				{Date: since},
-				{Date: after},
-			}
-		},
-	)()
+	cmits := collected(commits.Since(
+		since,
+		stream(
+			&commits.Commit{Date: before},
+			&commits.Commit{Date: since},
+			&commits.Commit{Date: after},
+		),
+	))
 
 	assert.Len(t, cmits, 2)
 	assert.Contains(t, cmits, &commits.Commit{Date: since})
 	assert.Contains(t, cmits, &commits.Commit{Date: after})
 }
 
-func TestMsgIn(t *testing.T) {
-	const message = "test subject\n\ntest body"
+func TestUntil(t *testing.T) {
+	before := time.Date(2017, 10, 25, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2019, 3, 3, 0, 0, 0, 0, time.UTC)
+	all := stream(
+		&commits.Commit{Date: before}, &commits.Commit{Date: until}, &commits.Commit{Date: after},
+	)
 
-	cmits := commits.MsgIn(strings.NewReader(message))()
+	assert.Equal(t,
+		[]*commits.Commit{{Date: before}, {Date: until}},
+		collected(commits.Until(until, all)),
+		"commits.Until() must return commits authored at or before the date")
+	assert.Len(t, collected(commits.Until(time.Time{}, all)), 3,
+		"commits.Until() must not filter any commits if the date is zero")
+}
 
-	assert.Len(t, cmits, 1)
-	assert.Equal(t, "test subject", cmits[0].Subject())
-	assert.Equal(t, "test body", cmits[0].Body())
+func TestMsgsIn(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"a.txt", "b.txt", "c.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(name+" subject"), 0o600))
+	}
+
+	cmts, err := commits.MsgsIn(
+		strings.NewReader("piped subject"),
+		[]string{filepath.Join(dir, "*.txt"), "-", filepath.Join(dir, "c.md")},
+	)
+	require.NoError(t, err)
+
+	labels := make([]string, 0)
+	subjects := make([]string, 0)
+
+	for c := range cmts {
+		labels = append(labels, c.ShortID())
+		subjects = append(subjects, c.Subject())
+	}
+
+	assert.Equal(t,
+		[]string{
+			filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt"), "stdin", filepath.Join(dir, "c.md"),
+		},
+		labels,
+		"commits.MsgsIn() must identify each message by its file's name",
+	)
+	assert.Equal(t,
+		[]string{"a.txt subject", "b.txt subject", "piped subject", "c.md subject"}, subjects,
+		"commits.MsgsIn() must read the message in each file, and in stdin for \"-\"")
+}
+
+func TestMsgsInNoMatches(t *testing.T) {
+	_, err := commits.MsgsIn(nil, []string{filepath.Join(t.TempDir(), "*.txt")})
+	assert.Error(t, err, "commits.MsgsIn() must fail if a pattern matches no files")
 }
 
 func TestWithMaxParents(t *testing.T) {
 	const max = 1
 
-	cmits := commits.WithMaxParents(max, func() []*commits.Commit {
-		return []*commits.Commit{
-			{NumParents: max},
-			{NumParents: 2},
-			{NumParents: 3},
-		}
-	})()
+	cmits := collected(commits.WithMaxParents(max, stream(
+		&commits.Commit{NumParents: max},
+		&commits.Commit{NumParents: 2},
+		&commits.Commit{NumParents: 3},
+	)))
 
 	assert.Len(t, cmits, 1)
 	assert.Equal(t, cmits[0].NumParents, max)
@@ -142,21 +265,50 @@ func TestNotAuthored(t *testing.T) {
 		{Author: randomAuthor()},
 	}
 
-	actual := commits.NotAuthoredByNames(
+	byNames, err := commits.NotAuthoredByNames(
 		[]string{filtered.Author.Name},
-		func() []*commits.Commit { return append(expected, filtered) },
-	)()
+		stream(append(expected, filtered)...),
+	)
+	require.NoError(t, err)
+
+	actual := collected(byNames)
 
 	assert.Equal(t, expected, actual)
 
-	actual = commits.NotAuthoredByEmails(
+	byEmails, err := commits.NotAuthoredByEmails(
 		[]string{filtered.Author.Email},
-		func() []*commits.Commit { return append(expected, filtered) },
-	)()
+		stream(append(expected, filtered)...),
+	)
+	require.NoError(t, err)
+
+	actual = collected(byEmails)
 
 	assert.Equal(t, expected, actual)
 }
 
+func TestNotAuthoredInvalid(t *testing.T) {
+	_, err := commits.NotAuthoredByNames([]string{"(", "valid", "["}, stream())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[(]",
+		"commits.NotAuthoredByNames() must report all invalid patterns")
+	assert.Contains(t, err.Error(), "[[]",
+		"commits.NotAuthoredByNames() must report all invalid patterns")
+
+	_, err = commits.NotAuthoredByEmails([]string{"("}, stream())
+	assert.Error(t, err,
+		"commits.NotAuthoredByEmails() must fail if a pattern is invalid")
+}
+
+// The commits in the stream.
+func collected(cmts commits.Commits) []*commits.Commit {
+	return slices.Collect(iter.Seq[*commits.Commit](cmts))
+}
+
+// A stream of these commits.
+func stream(cmts ...*commits.Commit) commits.Commits {
+	return commits.Commits(slices.Values(cmts))
+}
+
 func randomAuthor() *commits.Author {
 	return &commits.Author{
 		Name:  uuid.New().String(),
@@ -165,37 +317,42 @@ func randomAuthor() *commits.Author {
 }
 
 // A git repo initialized and with one commit per each of the messages provided.
-// This repo is created in a temporary directory; use the cleanup function
-// to delete it afterwards.
+// This repo is created in a temporary directory that is removed when the test ends.
 func tmpRepo(t *testing.T, msgs ...string) repo.Repo {
+	return repo.Filesystem(tmpRepoDir(t, msgs...))
+}
+
+// The directory of a git repo initialized and with one commit per each of the
+// messages provided.
+func tmpRepoDir(t *testing.T, msgs ...string) string {
 	folder := t.TempDir()
 
-	return func() *git.Repository {
-		r, err := git.PlainInit(folder, false)
-		require.NoError(t, err)
+	r, err := git.PlainInit(folder, false)
+	require.NoError(t, err)
 
-		wt, err := r.Worktree()
-		require.NoError(t, err)
+	wt, err := r.Worktree()
+	require.NoError(t, err)
 
-		for i, msg := range msgs {
-			file := fmt.Sprintf("msg%d.txt", i)
+	for i, msg := range msgs {
+		file := fmt.Sprintf("msg%d.txt", i)
 
-			err = os.WriteFile(filepath.Join(folder, file), []byte(msg), 0600)
-			require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(folder, file), []byte(msg), 0600)
+		require.NoError(t, err)
 
-			_, err = wt.Add(file)
-			require.NoError(t, err)
+		_, err = wt.Add(file)
+		require.NoError(t, err)
 
-			_, err = wt.Commit(msg, &git.CommitOptions{
-				Author: &object.Signature{
-					Name:  "John Doe",
-					Email: "john@doe.org",
-					When:  time.Now(),
-				},
-			})
-			require.NoError(t, err)
-		}
+		_, err = wt.Commit(msg, &git.CommitOptions{Author: testSignature()})
+		require.NoError(t, err)
+	}
+
+	return folder
+}
 
-		return r
+func testSignature() *object.Signature {
+	return &object.Signature{
+		Name:  "John Doe",
+		Email: "john@doe.org",
+		When:  time.Now(),
 	}
 }
@@ -0,0 +1,133 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// layouts are the absolute date formats accepted by ParseDate, tried in order.
+// Layouts without a zone are interpreted as UTC.
+var layouts = []string{ //nolint:gochecknoglobals // read-only
+	"2006-01-02",
+	"2006-01-02 -0700",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05Z07:00",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+var relative = regexp.MustCompile(`(\d+)([a-z]+)`) //nolint:gochecknoglobals // read-only
+
+// ParseDate parses an absolute or relative date.
+//
+// Absolute dates may be given as "yyyy-MM-dd", RFC 3339 timestamps, or any of
+// the layouts above with or without a timezone offset, or as "@<unix seconds>".
+// Relative dates are amounts of time before now in the style of git, eg.
+// "2.weeks.ago", "3 days ago", "1.year.2.months.ago", or in short form, eg.
+// "90d", "12h" or "1w2d". The units are s(econd), m(inute), h(our), d(ay),
+// w(eek), mo(nth) and y(ear).
+func ParseDate(expr string, now time.Time) (time.Time, error) {
+	expr = strings.TrimSpace(expr)
+
+	if expr == "now" {
+		return now, nil
+	}
+
+	if secs, found := strings.CutPrefix(expr, "@"); found {
+		unix, err := strconv.ParseInt(secs, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid unix timestamp [%s]", expr)
+		}
+
+		return time.Unix(unix, 0), nil
+	}
+
+	for _, l := range layouts {
+		if t, err := time.Parse(l, expr); err == nil {
+			return t, nil
+		}
+	}
+
+	return ago(expr, now)
+}
+
+func ago(expr string, now time.Time) (time.Time, error) {
+	compact := strings.NewReplacer(".", "", "_", "", " ", "").Replace(strings.ToLower(expr))
+	compact = strings.TrimSuffix(compact, "ago")
+	parts := relative.FindAllStringSubmatch(compact, -1)
+
+	if compact == "" || len(parts) == 0 || consumed(parts) != len(compact) {
+		return time.Time{}, fmt.Errorf(
+			`invalid date [%s]: use "yyyy-MM-dd", RFC 3339 `+
+				`or a relative date like "2.weeks.ago" or "90d"`,
+			expr,
+		)
+	}
+
+	t := now
+
+	for _, p := range parts {
+		n, err := strconv.Atoi(p[1])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid amount [%s] in date [%s]", p[1], expr)
+		}
+
+		if t, err = before(t, n, p[2]); err != nil {
+			return time.Time{}, fmt.Errorf("%w in date [%s]", err, expr)
+		}
+	}
+
+	return t, nil
+}
+
+func consumed(parts [][]string) int {
+	n := 0
+
+	for _, p := range parts {
+		n += len(p[0])
+	}
+
+	return n
+}
+
+//nolint:gocyclo // flat mapping of units
+func before(t time.Time, n int, unit string) (time.Time, error) {
+	switch unit {
+	case "s", "sec", "secs", "second", "seconds":
+		return t.Add(-time.Duration(n) * time.Second), nil
+	case "m", "min", "mins", "minute", "minutes":
+		return t.Add(-time.Duration(n) * time.Minute), nil
+	case "h", "hour", "hours":
+		return t.Add(-time.Duration(n) * time.Hour), nil
+	case "d", "day", "days":
+		return t.AddDate(0, 0, -n), nil
+	case "w", "week", "weeks":
+		return t.AddDate(0, 0, -7*n), nil
+	case "mo", "month", "months":
+		return t.AddDate(0, -n, 0), nil
+	case "y", "year", "years":
+		return t.AddDate(-n, 0, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown unit [%s]", unit)
+	}
+}
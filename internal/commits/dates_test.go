@@ -0,0 +1,73 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+func TestParseDateAbsolute(t *testing.T) {
+	plus2 := time.FixedZone("", 2*60*60)
+	tests := map[string]time.Time{
+		"2019-01-01":                time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		"2019-01-01 +0200":          time.Date(2019, 1, 1, 0, 0, 0, 0, plus2),
+		"2019-01-01T10:30:00+02:00": time.Date(2019, 1, 1, 10, 30, 0, 0, plus2),
+		"2019-01-01T10:30:00Z":      time.Date(2019, 1, 1, 10, 30, 0, 0, time.UTC),
+		"2019-01-01 10:30:00":       time.Date(2019, 1, 1, 10, 30, 0, 0, time.UTC),
+		"2019-01-01 10:30:00 +0200": time.Date(2019, 1, 1, 10, 30, 0, 0, plus2),
+		"@1546338600":               time.Date(2019, 1, 1, 10, 30, 0, 0, time.UTC),
+	}
+
+	for expr, expected := range tests {
+		actual, err := commits.ParseDate(expr, time.Now())
+		require.NoError(t, err, expr)
+		assert.True(t, expected.Equal(actual),
+			"commits.ParseDate(%q) returned %s instead of %s", expr, actual, expected)
+	}
+}
+
+func TestParseDateRelative(t *testing.T) {
+	now := time.Date(2019, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"now":                 now,
+		"2.weeks.ago":         time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+		"3 days ago":          time.Date(2019, 3, 12, 12, 0, 0, 0, time.UTC),
+		"90d":                 time.Date(2018, 12, 15, 12, 0, 0, 0, time.UTC),
+		"12h":                 time.Date(2019, 3, 15, 0, 0, 0, 0, time.UTC),
+		"1w2d":                time.Date(2019, 3, 6, 12, 0, 0, 0, time.UTC),
+		"1.year.2.months.ago": time.Date(2018, 1, 15, 12, 0, 0, 0, time.UTC),
+	}
+
+	for expr, expected := range tests {
+		actual, err := commits.ParseDate(expr, now)
+		require.NoError(t, err, expr)
+		assert.Equal(t, expected, actual, "commits.ParseDate(%q)", expr)
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"", "yesterday-ish", "2.fortnights.ago", "2019-13-45", "@soon", "d90",
+	} {
+		_, err := commits.ParseDate(expr, time.Now())
+		assert.Error(t, err, "commits.ParseDate(%q) must fail", expr)
+	}
+}
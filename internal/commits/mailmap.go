@@ -0,0 +1,259 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	git "github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing/object"
+
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+const mailmapFile = ".mailmap"
+
+// Mailmap is the list of mappings found in a .mailmap file.
+// See https://git-scm.com/docs/gitmailmap.
+type Mailmap func() []*Mapping
+
+// Mapping is a single .mailmap entry mapping the identity recorded in
+// commits to a proper identity. Empty Proper* fields leave the corresponding
+// part of the identity untouched; an empty CommitName matches any name.
+type Mapping struct {
+	ProperName  string
+	ProperEmail string
+	CommitName  string
+	CommitEmail string
+}
+
+// MailmapIn parses the .mailmap read from this reader.
+func MailmapIn(reader io.Reader) Mailmap {
+	return func() []*Mapping {
+		mappings := make([]*Mapping, 0)
+		scanner := bufio.NewScanner(reader)
+
+		for scanner.Scan() {
+			if m := parseMapping(scanner.Text()); m != nil {
+				mappings = append(mappings, m)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			panic(err)
+		}
+
+		return mappings
+	}
+}
+
+// MailmapOf returns the .mailmap found in the repository's worktree or, if
+// the repository is bare or the worktree has none, in HEAD's tree.
+// The mailmap is empty if neither is found.
+func MailmapOf(repository repo.Repo) Mailmap {
+	return func() []*Mapping {
+		r := repository()
+
+		if reader := worktreeMailmap(r); reader != nil {
+			defer reader.Close()
+
+			return MailmapIn(reader)()
+		}
+
+		if contents, found := headMailmap(r); found {
+			return MailmapIn(strings.NewReader(contents))()
+		}
+
+		return []*Mapping{}
+	}
+}
+
+// Mailmapped replaces the author and committer identities of the commits with
+// their canonical forms according to the mailmap. Identities known to the
+// mailmap, either because an entry matched them or because they already are
+// a proper identity listed in it, are marked as Canonical.
+func Mailmapped(mailmap Mailmap, cmts Commits) Commits {
+	return func(yield func(*Commit) bool) {
+		mappings := mailmap()
+
+		for c := range cmts {
+			mapped := *c
+			mapped.Author = canonical(mappings, c.Author)
+			mapped.Committer = canonical(mappings, c.Committer)
+
+			if !yield(&mapped) {
+				return
+			}
+		}
+	}
+}
+
+func worktreeMailmap(r *git.Repository) io.ReadCloser {
+	wt, err := r.Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return nil
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	file, err := wt.Filesystem.Open(mailmapFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	return file
+}
+
+func headMailmap(r *git.Repository) (contents string, found bool) {
+	ref, err := r.Head()
+	if err != nil {
+		return "", false
+	}
+
+	head, err := r.CommitObject(ref.Hash())
+	if err != nil {
+		panic(err)
+	}
+
+	file, err := head.File(mailmapFile)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return "", false
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	contents, err = file.Contents()
+	if err != nil {
+		panic(err)
+	}
+
+	return contents, true
+}
+
+// parseMapping parses a single line in one of these forms:
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+//
+// Blank lines, comments and malformed lines yield nil.
+func parseMapping(line string) *Mapping {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return nil
+	}
+
+	name1, email1, rest, ok := nameAndEmail(line)
+	if !ok {
+		return nil
+	}
+
+	name2, email2, _, ok := nameAndEmail(rest)
+	if !ok {
+		return &Mapping{ProperName: name1, CommitEmail: email1}
+	}
+
+	return &Mapping{
+		ProperName:  name1,
+		ProperEmail: email1,
+		CommitName:  name2,
+		CommitEmail: email2,
+	}
+}
+
+func nameAndEmail(s string) (name, email, rest string, ok bool) {
+	start := strings.Index(s, "<")
+	end := strings.Index(s, ">")
+
+	if start < 0 || end < start {
+		return "", "", "", false
+	}
+
+	return strings.TrimSpace(s[:start]), strings.TrimSpace(s[start+1 : end]), s[end+1:], true
+}
+
+func canonical(mappings []*Mapping, author *Author) *Author {
+	if author == nil {
+		return nil
+	}
+
+	mapped := *author
+
+	if m := lookup(mappings, author); m != nil {
+		if m.ProperName != "" {
+			mapped.Name = m.ProperName
+		}
+
+		if m.ProperEmail != "" {
+			mapped.Email = m.ProperEmail
+		}
+
+		mapped.Canonical = true
+
+		return &mapped
+	}
+
+	mapped.Canonical = isProper(mappings, author)
+
+	return &mapped
+}
+
+// lookup returns the mapping for the identity, preferring entries that match
+// on both name and email over those matching on email alone.
+func lookup(mappings []*Mapping, author *Author) *Mapping {
+	var byEmail *Mapping
+
+	for _, m := range mappings {
+		if !strings.EqualFold(m.CommitEmail, author.Email) {
+			continue
+		}
+
+		if m.CommitName == "" {
+			if byEmail == nil {
+				byEmail = m
+			}
+
+			continue
+		}
+
+		if strings.EqualFold(m.CommitName, author.Name) {
+			return m
+		}
+	}
+
+	return byEmail
+}
+
+func isProper(mappings []*Mapping, author *Author) bool {
+	for _, m := range mappings {
+		if m.ProperEmail != "" && strings.EqualFold(m.ProperEmail, author.Email) {
+			return true
+		}
+	}
+
+	return false
+}
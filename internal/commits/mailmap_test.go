@@ -0,0 +1,131 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+const mailmap = `# comments are ignored
+Jane Doe <jane@old.org>
+<john@new.org> <john@old.org>
+Joe Bloggs <joe@new.org> <joe@old.org>
+Joe Bloggs <joe@new.org> Joey <joe@shared.org>
+`
+
+func TestMailmapIn(t *testing.T) {
+	assert.Equal(t,
+		[]*commits.Mapping{
+			{ProperName: "Jane Doe", CommitEmail: "jane@old.org"},
+			{ProperEmail: "john@new.org", CommitEmail: "john@old.org"},
+			{ProperName: "Joe Bloggs", ProperEmail: "joe@new.org", CommitEmail: "joe@old.org"},
+			{
+				ProperName: "Joe Bloggs", ProperEmail: "joe@new.org",
+				CommitName: "Joey", CommitEmail: "joe@shared.org",
+			},
+		},
+		commits.MailmapIn(strings.NewReader(mailmap))(),
+		"commits.MailmapIn() must parse all forms of .mailmap entries")
+}
+
+func TestMailmapped(t *testing.T) {
+	cmits := collected(commits.Mailmapped(
+		commits.MailmapIn(strings.NewReader(mailmap)),
+		stream(
+			&commits.Commit{
+				Author:    &commits.Author{Name: "jdoe", Email: "JANE@old.org"},
+				Committer: &commits.Author{Name: "John", Email: "john@old.org"},
+			},
+			&commits.Commit{
+				Author:    &commits.Author{Name: "Joey", Email: "joe@shared.org"},
+				Committer: &commits.Author{Name: "Someone", Email: "joe@shared.org"},
+			},
+			&commits.Commit{
+				Author:    &commits.Author{Name: "Joe", Email: "joe@new.org"},
+				Committer: &commits.Author{Name: "Stranger", Email: "stranger@test.com"},
+			},
+		),
+	))
+
+	assert.Equal(t,
+		[]*commits.Commit{
+			{
+				Author:    &commits.Author{Name: "Jane Doe", Email: "JANE@old.org", Canonical: true},
+				Committer: &commits.Author{Name: "John", Email: "john@new.org", Canonical: true},
+			},
+			{
+				Author:    &commits.Author{Name: "Joe Bloggs", Email: "joe@new.org", Canonical: true},
+				Committer: &commits.Author{Name: "Someone", Email: "joe@shared.org"},
+			},
+			{
+				Author:    &commits.Author{Name: "Joe", Email: "joe@new.org", Canonical: true},
+				Committer: &commits.Author{Name: "Stranger", Email: "stranger@test.com"},
+			},
+		},
+		cmits,
+		"commits.Mailmapped() must canonicalise the identities known to the mailmap")
+}
+
+func TestMailmapOfWorktree(t *testing.T) {
+	r := tmpRepo(t, "first")
+
+	wt, err := r().Worktree()
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(wt.Filesystem.Root(), ".mailmap"), []byte(mailmap), 0600)
+	require.NoError(t, err)
+
+	assert.Len(t, commits.MailmapOf(r)(), 4,
+		"commits.MailmapOf() must read the .mailmap in the worktree")
+}
+
+func TestMailmapOfHead(t *testing.T) {
+	r := tmpRepo(t, "first")
+
+	wt, err := r().Worktree()
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(wt.Filesystem.Root(), ".mailmap"), []byte(mailmap), 0600)
+	require.NoError(t, err)
+
+	_, err = wt.Add(".mailmap")
+	require.NoError(t, err)
+
+	_, err = wt.Commit("add mailmap", &git.CommitOptions{Author: testSignature()})
+	require.NoError(t, err)
+
+	bare := filepath.Join(t.TempDir(), "bare.git")
+
+	_, err = git.PlainClone(bare, &git.CloneOptions{URL: wt.Filesystem.Root(), Bare: true})
+	require.NoError(t, err)
+
+	assert.Len(t, commits.MailmapOf(repo.Filesystem(bare))(), 4,
+		"commits.MailmapOf() must read the .mailmap in HEAD's tree of bare repos")
+}
+
+func TestMailmapOfNone(t *testing.T) {
+	assert.Empty(t, commits.MailmapOf(tmpRepo(t, "first"))(),
+		"commits.MailmapOf() must be empty if the repo has no .mailmap")
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ( //nolint:gochecknoglobals // read-only
+	// subjectPrefixes are the "[PATCH v2 3/7]" and "Re:" prefixes that
+	// `git am` strips from subjects.
+	subjectPrefixes = regexp.MustCompile(`^(?:\s*(?:\[[^\]]*\]|(?i:re|fwd?):))*\s*`)
+	patchNumber     = regexp.MustCompile(`^\s*\[[^\]]*?(\d+)/\d+\]`)
+	escapedFrom     = regexp.MustCompile(`^>+From `)
+	// mboxSeparator is a "From <sender> <date>" line, with the date as in
+	// "Mon Sep 17 00:00:00 2001".
+	mboxSeparator = regexp.MustCompile(
+		`^From \S+ +[A-Z][a-z]{2} [A-Z][a-z]{2} +\d{1,2} \d\d:\d\d(?::\d\d)? \d{4}\b`,
+	)
+	// messageEnd is where the message ends and the patch starts, as in `git am`.
+	messageEnd = regexp.MustCompile(`(?m)^(?:---[ \t]*$|diff -|Index: )`)
+)
+
+// PatchesIn returns a fake commit for each patch in the mbox or `git
+// format-patch` files matching the glob patterns, with the patch's author,
+// date and message as `git am` would commit it. Each is identified by the
+// file's name and the patch's number in the series, or else in the file.
+// Cover letters, numbered 0 in the series, are skipped.
+// The pattern "-" is the mbox read from stdin.
+// It fails if a pattern is invalid or matches no files, or if a patch is
+// invalid.
+func PatchesIn(stdin io.Reader, patterns []string) (Commits, error) {
+	files, err := filesOf("patch", patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	patches := make([]*Commit, 0)
+	errs := make([]error, 0)
+
+	for _, f := range files {
+		name, b := read(stdin, f)
+
+		for i, email := range emailsIn(b) {
+			c, err := patchOf(email)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid patch #%d in %s: %w", i+1, name, err))
+
+				continue
+			}
+
+			n := numberOf(c.Hash, i+1)
+			if n == 0 {
+				// the cover letter of `git format-patch --cover-letter`
+				continue
+			}
+
+			c.Hash = fmt.Sprintf("%s:%d", name, n)
+			patches = append(patches, c)
+		}
+	}
+
+	return Commits(slices.Values(patches)), errors.Join(errs...)
+}
+
+// emailsIn splits the mbox into its emails, separated by "From " lines that
+// end in a date as `git mailsplit` requires, so that the unescaped "From "
+// lines in the bodies of `git format-patch` files don't split them. The
+// ">From " lines of mboxrd files are unescaped. Files not starting with a
+// separator are a single email.
+func emailsIn(mbox []byte) [][]byte {
+	emails := make([]*bytes.Buffer, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(mbox))
+	scanner.Buffer(nil, len(mbox)+1)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		separator := mboxSeparator.Match(line)
+
+		if separator || len(emails) == 0 {
+			emails = append(emails, &bytes.Buffer{})
+		}
+
+		if separator {
+			continue
+		}
+
+		if escapedFrom.Match(line) {
+			line = line[1:]
+		}
+
+		emails[len(emails)-1].Write(append(line, '\n'))
+	}
+
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+
+	contents := make([][]byte, 0, len(emails))
+
+	for _, e := range emails {
+		contents = append(contents, e.Bytes())
+	}
+
+	return contents
+}
+
+// patchOf returns the commit of the patch in the email. Its hash is the raw
+// subject, for numberOf.
+func patchOf(email []byte) (*Commit, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(email))
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := &mime.WordDecoder{}
+
+	subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid From: %w", err)
+	}
+
+	date, err := msg.Header.Date()
+	if err != nil {
+		return nil, fmt.Errorf("invalid Date: %w", err)
+	}
+
+	body, err := io.ReadAll(decoded(msg.Header.Get("Content-Transfer-Encoding"), msg.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	if loc := messageEnd.FindIndex(body); loc != nil {
+		body = body[:loc[0]]
+	}
+
+	message := subjectPrefixes.ReplaceAllString(subject, "")
+	if b := strings.TrimSpace(string(body)); b != "" {
+		message += "\n\n" + b
+	}
+
+	return &Commit{
+		Hash:    subject,
+		Message: message + "\n",
+		Date:    date,
+		Author:  &Author{Name: from.Name, Email: from.Address},
+	}, nil
+}
+
+func decoded(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	default:
+		return body
+	}
+}
+
+// numberOf returns the patch's number in "[PATCH 3/7]" subjects, or else n.
+func numberOf(subject string, n int) int {
+	if m := patchNumber.FindStringSubmatch(subject); m != nil {
+		if number, err := strconv.Atoi(m[1]); err == nil {
+			return number
+		}
+	}
+
+	return n
+}
+
+// read returns the file's name and contents, or "stdin" and its contents for "-".
+func read(stdin io.Reader, file string) (string, []byte) {
+	var (
+		b   []byte
+		err error
+	)
+
+	if file == "-" {
+		file = "stdin"
+		b, err = io.ReadAll(stdin)
+	} else {
+		b, err = os.ReadFile(file) //nolint:gosec // the user's own files
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	return file, b
+}
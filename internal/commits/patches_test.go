@@ -0,0 +1,153 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+const series = `From 1111111111111111111111111111111111111111 Mon Sep 17 00:00:00 2001
+From: John Doe <john@doe.org>
+Date: Tue, 3 Feb 2026 10:00:00 +0100
+Subject: [PATCH v2 1/2] Add the
+ feature
+
+It is needed.
+>From now on, it's there.
+
+Signed-off-by: John Doe <john@doe.org>
+---
+ file | 1 +
+ 1 file changed, 1 insertion(+)
+
+diff --git a/file b/file
+--- /dev/null
++++ b/file
+@@ -0,0 +1 @@
++From here
+From 2222222222222222222222222222222222222222 Mon Sep 17 00:00:00 2001
+From: =?UTF-8?q?Jos=C3=A9?= <jose@doe.org>
+Date: Wed, 4 Feb 2026 10:00:00 +0100
+Subject: [PATCH v2 2/2] =?UTF-8?q?Fix=20the=20caf=C3=A9?=
+Content-Transfer-Encoding: quoted-printable
+
+Caf=C3=A9 fix.
+diff --git a/file b/file
+`
+
+func TestPatchesIn(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "series.mbox")
+	require.NoError(t, os.WriteFile(file, []byte(series), 0o600))
+
+	patches, err := commits.PatchesIn(nil, []string{file})
+	require.NoError(t, err)
+
+	cmts := collected(patches)
+	require.Len(t, cmts, 2)
+
+	assert.Equal(t, file+":1", cmts[0].ShortID(),
+		"commits.PatchesIn() must identify patches by file and number in the series")
+	assert.Equal(t,
+		"Add the feature\n\nIt is needed.\nFrom now on, it's there.\n\n"+
+			"Signed-off-by: John Doe <john@doe.org>\n",
+		cmts[0].Message,
+		"commits.PatchesIn() must strip the subject's prefixes and the patch from the message")
+	assert.Equal(t, &commits.Author{Name: "John Doe", Email: "john@doe.org"}, cmts[0].Author,
+		"commits.PatchesIn() must read the patch's author")
+	assert.True(t, time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC).Equal(cmts[0].Date),
+		"commits.PatchesIn() must read the patch's date")
+
+	assert.Equal(t, file+":2", cmts[1].ShortID(),
+		"commits.PatchesIn() must identify patches by file and number in the series")
+	assert.Equal(t, "Fix the café\n\nCafé fix.\n", cmts[1].Message,
+		"commits.PatchesIn() must decode encoded subjects and bodies")
+	assert.Equal(t, "José", cmts[1].Author.Name, "commits.PatchesIn() must decode encoded names")
+}
+
+func TestPatchesInCoverLetter(t *testing.T) {
+	const cover = `From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001
+From: John Doe <john@doe.org>
+Date: Tue, 3 Feb 2026 10:00:00 +0100
+Subject: [PATCH v2 0/2] *** SUBJECT HERE ***
+
+*** BLURB HERE ***
+
+John Doe (2):
+  Add the feature
+  Fix the cafe
+
+`
+
+	file := filepath.Join(t.TempDir(), "series.mbox")
+	require.NoError(t, os.WriteFile(file, []byte(cover+series), 0o600))
+
+	patches, err := commits.PatchesIn(nil, []string{file})
+	require.NoError(t, err)
+
+	cmts := collected(patches)
+	require.Len(t, cmts, 2, "commits.PatchesIn() must skip cover letters")
+	assert.Equal(t, file+":1", cmts[0].ShortID())
+}
+
+func TestPatchesInUnescapedFrom(t *testing.T) {
+	const patch = `From 1111111111111111111111111111111111111111 Mon Sep 17 00:00:00 2001
+From: John Doe <john@doe.org>
+Date: Tue, 3 Feb 2026 10:00:00 +0100
+Subject: [PATCH] Add the feature
+
+From now on, it's there.
+From 10:00:00 to 2026, too.
+---
+ file | 1 +
+`
+
+	patches, err := commits.PatchesIn(strings.NewReader(patch), []string{"-"})
+	require.NoError(t, err)
+
+	cmts := collected(patches)
+	require.Len(t, cmts, 1, "commits.PatchesIn() must not split patches at \"From \" lines in bodies")
+	assert.Equal(t, "Add the feature\n\nFrom now on, it's there.\nFrom 10:00:00 to 2026, too.\n",
+		cmts[0].Message)
+}
+
+func TestPatchesInInvalid(t *testing.T) {
+	_, err := commits.PatchesIn(strings.NewReader("not an email\n"), []string{"-"})
+	assert.Error(t, err, "commits.PatchesIn() must fail on invalid patches")
+}
+
+func TestPatchesInStdin(t *testing.T) {
+	patches, err := commits.PatchesIn(
+		strings.NewReader("From: John Doe <john@doe.org>\nDate: Tue, 3 Feb 2026 10:00:00 +0100\n"+
+			"Subject: Re: [PATCH] Fix\n\nBody\n"),
+		[]string{"-"},
+	)
+	require.NoError(t, err)
+
+	cmts := collected(patches)
+	require.Len(t, cmts, 1)
+	assert.Equal(t, "stdin:1", cmts[0].ShortID(),
+		"commits.PatchesIn() must number patches by their position if their subjects don't")
+	assert.Equal(t, "Fix\n\nBody\n", cmts[0].Message,
+		"commits.PatchesIn() must read the email in stdin")
+}
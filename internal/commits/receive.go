@@ -0,0 +1,167 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	git "github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/go-git/go-git/v6/plumbing/storer"
+
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+// Update is a ref update pushed to a repository.
+type Update struct {
+	Old string
+	New string
+	Ref string
+}
+
+// UpdatesIn parses the "<old> <new> <ref>" lines that git gives pre-receive
+// hooks in their standard input. See githooks(5).
+func UpdatesIn(reader io.Reader) ([]*Update, error) {
+	updates := make([]*Update, 0)
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid ref update [%s]", scanner.Text())
+		}
+
+		updates = append(updates, &Update{Old: fields[0], New: fields[1], Ref: fields[2]})
+	}
+
+	return updates, scanner.Err()
+}
+
+// Created tells whether the update creates the ref.
+func (u *Update) Created() bool {
+	return isZero(u.Old)
+}
+
+// Deleted tells whether the update deletes the ref.
+func (u *Update) Deleted() bool {
+	return isZero(u.New)
+}
+
+// Received returns the commits that each update brings into the repository:
+// those reachable from its new value but not from any of the repository's
+// refs, which pre-receive hooks still see as they were before the push.
+// Deletions bring no commits, and neither do refs to objects other than
+// commits or tags of commits. The commits reachable from the refs are only
+// walked once, the first time they're needed, for all the updates.
+func Received(repository repo.Repo) func(update *Update) Commits {
+	known := sync.OnceValue(func() map[plumbing.Hash]bool { return reachable(repository()) })
+
+	return func(update *Update) Commits {
+		return func(yield func(*Commit) bool) {
+			if update.Deleted() {
+				return
+			}
+
+			r := repository()
+
+			tip, found := peeled(r, plumbing.NewHash(update.New))
+			if !found {
+				return
+			}
+
+			storage := &sync.Mutex{}
+			walk := object.NewCommitPreorderIter(tip, known(), nil)
+
+			err := forEach(storage, walk, func(c *object.Commit) error {
+				if !yield(commitOf(c, storage)) {
+					return storer.ErrStop
+				}
+
+				return nil
+			})
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// reachable returns the commits reachable from any of the repository's refs.
+func reachable(r *git.Repository) map[plumbing.Hash]bool {
+	seen := make(map[plumbing.Hash]bool)
+
+	refs, err := r.References()
+	if err != nil {
+		panic(err)
+	}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		tip, found := peeled(r, ref.Hash())
+		if !found {
+			return nil
+		}
+
+		return object.NewCommitPreorderIter(tip, seen, nil).ForEach(func(c *object.Commit) error {
+			seen[c.Hash] = true
+
+			return nil
+		})
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return seen
+}
+
+// peeled returns the commit that the object is or that the tag points to.
+func peeled(r *git.Repository, h plumbing.Hash) (*object.Commit, bool) {
+	obj, err := r.Object(plumbing.AnyObject, h)
+	if err != nil {
+		panic(err)
+	}
+
+	switch o := obj.(type) {
+	case *object.Commit:
+		return o, true
+	case *object.Tag:
+		c, err := o.Commit()
+		if err != nil {
+			return nil, false
+		}
+
+		return c, true
+	default:
+		return nil, false
+	}
+}
+
+func isZero(hash string) bool {
+	return strings.Trim(hash, "0") == ""
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+const zero = "0000000000000000000000000000000000000000"
+
+func TestUpdatesIn(t *testing.T) {
+	updates, err := commits.UpdatesIn(strings.NewReader(
+		zero + " 1111111111111111111111111111111111111111 refs/heads/feature\n" +
+			"2222222222222222222222222222222222222222 " + zero + " refs/heads/old\n",
+	))
+	require.NoError(t, err)
+	assert.Equal(t,
+		[]*commits.Update{
+			{Old: zero, New: "1111111111111111111111111111111111111111", Ref: "refs/heads/feature"},
+			{Old: "2222222222222222222222222222222222222222", New: zero, Ref: "refs/heads/old"},
+		},
+		updates,
+		"commits.UpdatesIn() must parse each ref update",
+	)
+	assert.True(t, updates[0].Created(),
+		"commits.Update.Created() must be true if the old value is the zero hash")
+	assert.True(t, updates[1].Deleted(),
+		"commits.Update.Deleted() must be true if the new value is the zero hash")
+}
+
+func TestUpdatesInInvalid(t *testing.T) {
+	_, err := commits.UpdatesIn(strings.NewReader("refs/heads/master\n"))
+	assert.Error(t, err, "commits.UpdatesIn() must fail on malformed lines")
+}
+
+func TestReceived(t *testing.T) {
+	r, old, pushed := pushedRepo(t)
+	received := commits.Received(r)
+
+	for _, test := range []struct {
+		update   *commits.Update
+		expected []string
+	}{
+		{
+			&commits.Update{Old: old, New: pushed, Ref: "refs/heads/master"},
+			[]string{"pushed2", "pushed1"},
+		},
+		{
+			&commits.Update{Old: zero, New: pushed, Ref: "refs/heads/feature"},
+			[]string{"pushed2", "pushed1"},
+		},
+		{&commits.Update{Old: zero, New: old, Ref: "refs/heads/feature"}, []string{}},
+		{&commits.Update{Old: old, New: zero, Ref: "refs/heads/master"}, []string{}},
+	} {
+		messages := make([]string, 0)
+
+		for c := range received(test.update) {
+			messages = append(messages, c.Message)
+		}
+
+		assert.Equal(t, test.expected, messages,
+			"commits.Received() must only return the commits not reachable from any ref: %+v",
+			test.update,
+		)
+	}
+}
+
+// pushedRepo returns a repo whose master has two commits, and two more
+// commits on top that no ref points to yet, as if they were being pushed.
+func pushedRepo(t *testing.T) (r repo.Repo, old, pushed string) {
+	dir := tmpRepoDir(t, "existing1", "existing2")
+
+	gr, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+
+	head, err := gr.Head()
+	require.NoError(t, err)
+
+	wt, err := gr.Worktree()
+	require.NoError(t, err)
+
+	for _, msg := range []string{"pushed1", "pushed2"} {
+		_, err = wt.Commit(msg, &git.CommitOptions{Author: testSignature(), AllowEmptyCommits: true})
+		require.NoError(t, err)
+	}
+
+	tip, err := gr.Head()
+	require.NoError(t, err)
+	require.NoError(t, gr.Storer.SetReference(plumbing.NewHashReference(head.Name(), head.Hash())))
+
+	return repo.Filesystem(dir), head.Hash().String(), tip.Hash().String()
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	git "github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+// Tags returns the repository's annotated tags, in name order, as commits
+// with the tag's message, and the tagger as author and committer. Only tags
+// whose names match the glob pattern are returned, with "*" matching "/" as
+// in Branches, and if a range like "v1.0..HEAD" is given, only those pointing
+// to commits reachable from its end but not from its start. Tags are named
+// after their refs, and lightweight tags have no message and are skipped.
+// It fails if the range is invalid or its revisions can't be resolved.
+func Tags(repository repo.Repo, pattern, revisions string) (Commits, error) {
+	glob := globOf(pattern)
+
+	from, to, isRange := strings.Cut(revisions, "..")
+	if revisions != "" && (!isRange || to == "") {
+		return nil, fmt.Errorf("invalid tag range [%s]: expected <from>..<to>", revisions)
+	}
+
+	var start, end *plumbing.Hash
+
+	if isRange {
+		var err error
+
+		if start, end, err = resolved(repository(), from, to); err != nil {
+			return nil, fmt.Errorf("invalid tag range [%s]: %w", revisions, err)
+		}
+	}
+
+	return func(yield func(*Commit) bool) {
+		r := repository()
+
+		var inRange map[plumbing.Hash]bool
+
+		if isRange {
+			inRange = between(r, start, end)
+		}
+
+		refs, err := r.Tags()
+		if err != nil {
+			panic(err)
+		}
+
+		matched := make([]*Commit, 0)
+
+		err = refs.ForEach(func(ref *plumbing.Reference) error {
+			name := ref.Name().Short()
+			if pattern != "" && !glob.MatchString(name) {
+				return nil
+			}
+
+			t, found := annotated(r, ref)
+			if !found {
+				return nil
+			}
+
+			if inRange == nil || t.TargetType == plumbing.CommitObject && inRange[t.Target] {
+				matched = append(matched, tagOf(name, t))
+			}
+
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		slices.SortFunc(matched, func(a, b *Commit) int { return strings.Compare(a.Tag, b.Tag) })
+
+		for _, c := range matched {
+			if !yield(c) {
+				return
+			}
+		}
+	}, nil
+}
+
+func tagOf(name string, t *object.Tag) *Commit {
+	tagger := &Author{Name: t.Tagger.Name, Email: t.Tagger.Email}
+
+	return &Commit{
+		Hash:       t.Hash.String(),
+		Tag:        name,
+		Message:    t.Message,
+		Date:       t.Tagger.When,
+		CommitDate: t.Tagger.When,
+		Author:     tagger,
+		Committer:  tagger,
+	}
+}
+
+// annotated returns the tag object that the ref points to, which is not found
+// for lightweight tags.
+func annotated(r *git.Repository, ref *plumbing.Reference) (*object.Tag, bool) {
+	t, err := r.TagObject(ref.Hash())
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, false
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	return t, true
+}
+
+// resolved returns the commits of the range's revisions. An empty from
+// revision has no commit.
+func resolved(r *git.Repository, from, to string) (start, end *plumbing.Hash, err error) {
+	if from != "" {
+		if start, err = r.ResolveRevision(plumbing.Revision(from)); err != nil {
+			return nil, nil, fmt.Errorf("cannot resolve [%s]: %w", from, err)
+		}
+	}
+
+	if end, err = r.ResolveRevision(plumbing.Revision(to)); err != nil {
+		return nil, nil, fmt.Errorf("cannot resolve [%s]: %w", to, err)
+	}
+
+	return start, end, nil
+}
+
+// between returns the commits reachable from the end but not from the start.
+// A nil start excludes no commits.
+func between(r *git.Repository, start, end *plumbing.Hash) map[plumbing.Hash]bool {
+	excluded := make(map[plumbing.Hash]bool)
+
+	if start != nil {
+		excluded = ancestors(r, *start, nil)
+	}
+
+	return ancestors(r, *end, excluded)
+}
+
+// ancestors returns the commits reachable from the commit, except those
+// already seen.
+func ancestors(
+	r *git.Repository, h plumbing.Hash, seen map[plumbing.Hash]bool,
+) map[plumbing.Hash]bool {
+	tip, err := r.CommitObject(h)
+	if err != nil {
+		panic(err)
+	}
+
+	found := make(map[plumbing.Hash]bool)
+
+	err = object.NewCommitPreorderIter(tip, seen, nil).ForEach(func(c *object.Commit) error {
+		found[c.Hash] = true
+
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return found
+}
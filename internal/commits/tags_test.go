@@ -0,0 +1,154 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commits_test
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+func TestTags(t *testing.T) {
+	r := taggedRepo(t)
+
+	for _, test := range []struct {
+		pattern   string
+		revisions string
+		expected  []string
+	}{
+		{"", "", []string{"release/v2", "v1.0.0", "v1.1.0", "v2.0.0"}},
+		{"*", "", []string{"release/v2", "v1.0.0", "v1.1.0", "v2.0.0"}},
+		{"v1.*", "", []string{"v1.0.0", "v1.1.0"}},
+		{"release/*", "", []string{"release/v2"}},
+		{"", "v1.0.0..HEAD", []string{"release/v2", "v1.1.0", "v2.0.0"}},
+		{"v1.*", "v1.0.0..HEAD", []string{"v1.1.0"}},
+	} {
+		tags, err := commits.Tags(r, test.pattern, test.revisions)
+		require.NoError(t, err)
+
+		names := make([]string, 0)
+
+		for c := range tags {
+			names = append(names, c.ShortID())
+		}
+
+		assert.Equal(t, test.expected, names,
+			"commits.Tags() must return the annotated tags matching %q in range %q",
+			test.pattern, test.revisions)
+	}
+}
+
+func TestTagsMessage(t *testing.T) {
+	tags, err := commits.Tags(taggedRepo(t), "v2.0.0", "")
+	require.NoError(t, err)
+
+	tag := collected(tags)[0]
+	assert.Equal(t, "Release v2.0.0\n\nBreaking changes.\n", tag.Message,
+		"commits.Tags() must return the tag's message")
+	assert.Equal(t, &commits.Author{Name: "Jane Doe", Email: "jane@doe.org"}, tag.Author,
+		"commits.Tags() must return the tagger as author")
+}
+
+func TestTagsRefs(t *testing.T) {
+	r := taggedRepo(t)
+	repository := r()
+
+	head, err := repository.Head()
+	require.NoError(t, err)
+
+	tagger := testSignature()
+
+	_, err = repository.CreateTag("deleted", head.Hash(),
+		&git.CreateTagOptions{Tagger: tagger, Message: "Deleted\n"})
+	require.NoError(t, err)
+	require.NoError(t, repository.DeleteTag("deleted"))
+
+	// a tag object created as "renamed" but only reachable as v3.0.0
+	renamed, err := repository.CreateTag("renamed", head.Hash(),
+		&git.CreateTagOptions{Tagger: tagger, Message: "Release v3.0.0\n"})
+	require.NoError(t, err)
+	require.NoError(t, repository.Storer.SetReference(
+		plumbing.NewHashReference("refs/tags/v3.0.0", renamed.Hash()),
+	))
+	require.NoError(t, repository.DeleteTag("renamed"))
+
+	tags, err := commits.Tags(r, "", "")
+	require.NoError(t, err)
+
+	names := make([]string, 0)
+
+	for c := range tags {
+		names = append(names, c.ShortID())
+	}
+
+	assert.Equal(t, []string{"release/v2", "v1.0.0", "v1.1.0", "v2.0.0", "v3.0.0"}, names,
+		"commits.Tags() must return the tags of the refs, named after them")
+}
+
+func TestTagsInvalid(t *testing.T) {
+	_, err := commits.Tags(nil, "", "v1.0.0")
+	assert.Error(t, err, "commits.Tags() must fail on invalid ranges")
+
+	_, err = commits.Tags(taggedRepo(t), "", "v1.0.0..nope")
+	assert.Error(t, err, "commits.Tags() must fail on ranges that can't be resolved")
+}
+
+// taggedRepo returns a repo with three commits, annotated tags v1.0.0 and
+// v1.1.0 on the first two and v2.0.0 and release/v2 on the last one, and a
+// lightweight tag.
+func taggedRepo(t *testing.T) repo.Repo {
+	dir := tmpRepoDir(t, "first", "second", "third")
+
+	r, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+
+	head, err := r.Head()
+	require.NoError(t, err)
+
+	c, err := r.CommitObject(head.Hash())
+	require.NoError(t, err)
+
+	tagger := testSignature()
+	tagger.Name, tagger.Email = "Jane Doe", "jane@doe.org"
+
+	for _, tag := range []struct{ name, msg string }{
+		{"v2.0.0", "Release v2.0.0\n\nBreaking changes.\n"},
+		{"v1.1.0", "Release v1.1.0\n"},
+		{"v1.0.0", "Release v1.0.0\n"},
+	} {
+		_, err = r.CreateTag(tag.name, c.Hash, &git.CreateTagOptions{Tagger: tagger, Message: tag.msg})
+		require.NoError(t, err)
+
+		if c.NumParents() > 0 {
+			c, err = c.Parent(0)
+			require.NoError(t, err)
+		}
+	}
+
+	_, err = r.CreateTag("release/v2", head.Hash(),
+		&git.CreateTagOptions{Tagger: tagger, Message: "Release 2\n"})
+	require.NoError(t, err)
+
+	_, err = r.CreateTag("lightweight", head.Hash(), nil)
+	require.NoError(t, err)
+
+	return repo.Filesystem(dir)
+}
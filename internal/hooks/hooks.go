@@ -0,0 +1,175 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hooks installs gitlint as a git hook.
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	git "github.com/go-git/go-git/v6"
+
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+const (
+	hook = "commit-msg"
+	// chained is what an existing hook is renamed to when gitlint is installed.
+	chained = hook + ".pre-gitlint"
+	marker  = "# Installed by gitlint."
+	script  = `#!/bin/sh
+` + marker + ` Remove with 'gitlint uninstall-hook'.
+chained="$(dirname "$0")/` + chained + `"
+if [ -x "$chained" ]; then
+	"$chained" "$@" || exit $?
+fi
+exec %s --msg-file="$1"
+`
+)
+
+// ErrForeignHook is returned when asked to remove a hook gitlint didn't install.
+var ErrForeignHook = errors.New("the " + hook + " hook was not installed by gitlint")
+
+// Dir returns the directory git runs the repository's hooks from: the
+// core.hooksPath if set, or else the hooks directory shared by all of the
+// repository's worktrees.
+func Dir(repository repo.Repo) string {
+	if dir := repo.Option(repository, "core", "hooksPath"); dir != "" {
+		if rest, found := strings.CutPrefix(dir, "~/"); found {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				panic(err)
+			}
+
+			dir = filepath.Join(home, rest)
+		}
+
+		if filepath.IsAbs(dir) {
+			return dir
+		}
+
+		// relative paths are relative to where hooks run
+		return filepath.Join(runDir(repository), dir)
+	}
+
+	return filepath.Join(commonDir(repo.GitDir(repository)), "hooks")
+}
+
+// Install writes a commit-msg hook into the directory that runs the command
+// with the path to the commit message file. An existing commit-msg hook not
+// installed by gitlint is kept and run before the command. Installing again
+// only updates the command.
+func Install(dir, command string) error {
+	path := filepath.Join(dir, hook)
+
+	installed, err := ours(path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	case !installed:
+		if _, err = os.Stat(filepath.Join(dir, chained)); err == nil {
+			return fmt.Errorf("cannot chain the existing %s hook: %s already exists", hook, chained)
+		}
+
+		if err = os.Rename(path, filepath.Join(dir, chained)); err != nil {
+			return err
+		}
+	}
+
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	contents := fmt.Sprintf(script, quoted(command))
+
+	return os.WriteFile(path, []byte(contents), 0o755) //nolint:gosec // hooks must be executable
+}
+
+// Uninstall removes the commit-msg hook installed by gitlint from the
+// directory, restoring the hook it chained, if any. It fails with
+// ErrForeignHook if the hook was not installed by gitlint.
+func Uninstall(dir string) error {
+	path := filepath.Join(dir, hook)
+
+	installed, err := ours(path)
+	if err != nil {
+		return err
+	}
+
+	if !installed {
+		return ErrForeignHook
+	}
+
+	if err = os.Remove(path); err != nil {
+		return err
+	}
+
+	err = os.Rename(filepath.Join(dir, chained), path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func ours(path string) (bool, error) {
+	b, err := os.ReadFile(path) //nolint:gosec // the repo's own hook
+	if err != nil {
+		return false, err
+	}
+
+	return strings.Contains(string(b), marker), nil
+}
+
+// runDir is where git runs hooks: the root of the worktree, or the git
+// directory of bare repositories.
+func runDir(repository repo.Repo) string {
+	wt, err := repository().Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return repo.GitDir(repository)
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	return wt.Filesystem.Root()
+}
+
+// commonDir follows the commondir file of linked worktrees' git directories
+// to the git directory they share with the main worktree.
+func commonDir(gitDir string) string {
+	b, err := os.ReadFile(filepath.Join(gitDir, "commondir")) //nolint:gosec // the repo's own file
+	if err != nil {
+		return gitDir
+	}
+
+	common := strings.TrimSpace(string(b))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(gitDir, common)
+	}
+
+	return common
+}
+
+// quoted quotes s for the shell.
+func quoted(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
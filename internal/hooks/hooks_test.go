@@ -0,0 +1,143 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hooks_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	git "github.com/go-git/go-git/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/hooks"
+	"github.com/llorllale/go-gitlint/internal/repo"
+)
+
+func TestDir(t *testing.T) {
+	dir := tmpRepo(t, "")
+
+	assert.Equal(t, filepath.Join(dir, ".git", "hooks"), hooks.Dir(repo.Filesystem(dir)),
+		"hooks.Dir() must default to the hooks directory in the git directory")
+}
+
+func TestDirHooksPath(t *testing.T) {
+	dir := tmpRepo(t, ".githooks")
+
+	assert.Equal(t, filepath.Join(dir, ".githooks"), hooks.Dir(repo.Filesystem(dir)),
+		"hooks.Dir() must resolve a relative core.hooksPath from the root of the worktree")
+
+	dir = tmpRepo(t, "/shared/hooks")
+
+	assert.Equal(t, "/shared/hooks", hooks.Dir(repo.Filesystem(dir)),
+		"hooks.Dir() must return an absolute core.hooksPath as is")
+}
+
+func TestInstall(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(t.TempDir(), "log")
+
+	require.NoError(t, hooks.Install(dir, fakeGitlint(t, log)))
+	run(t, dir, "MSG")
+
+	assert.Equal(t, "gitlint --msg-file=MSG\n", read(t, log),
+		"hooks.Install() must write a hook running gitlint on the message file")
+}
+
+func TestInstallChains(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(t.TempDir(), "log")
+
+	writeHook(t, dir, "#!/bin/sh\necho \"existing $1\" >> "+log+"\n")
+	require.NoError(t, hooks.Install(dir, fakeGitlint(t, log)))
+	require.NoError(t, hooks.Install(dir, fakeGitlint(t, log)))
+	run(t, dir, "MSG")
+
+	assert.Equal(t, "existing MSG\ngitlint --msg-file=MSG\n", read(t, log),
+		"hooks.Install() must run the existing hook before gitlint")
+}
+
+func TestUninstall(t *testing.T) {
+	dir := t.TempDir()
+	existing := "#!/bin/sh\necho existing\n"
+
+	writeHook(t, dir, existing)
+	require.NoError(t, hooks.Install(dir, "gitlint"))
+	require.NoError(t, hooks.Uninstall(dir))
+
+	assert.Equal(t, existing, read(t, filepath.Join(dir, "commit-msg")),
+		"hooks.Uninstall() must restore the chained hook")
+	assert.NoFileExists(t, filepath.Join(dir, "commit-msg.pre-gitlint"),
+		"hooks.Uninstall() must restore the chained hook")
+}
+
+func TestUninstallForeign(t *testing.T) {
+	dir := t.TempDir()
+
+	writeHook(t, dir, "#!/bin/sh\necho existing\n")
+
+	assert.ErrorIs(t, hooks.Uninstall(dir), hooks.ErrForeignHook,
+		"hooks.Uninstall() must refuse to remove hooks gitlint didn't install")
+	assert.FileExists(t, filepath.Join(dir, "commit-msg"),
+		"hooks.Uninstall() must not remove hooks gitlint didn't install")
+}
+
+// tmpRepo returns the path to a new repo with this core.hooksPath, isolated
+// from the user's and the system's configuration.
+func tmpRepo(t *testing.T, hooksPath string) string {
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	dir := t.TempDir()
+
+	r, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+
+	if hooksPath != "" {
+		cfg, err := r.Config()
+		require.NoError(t, err)
+		cfg.Raw.Section("core").SetOption("hooksPath", hooksPath)
+		require.NoError(t, r.SetConfig(cfg))
+	}
+
+	return dir
+}
+
+// fakeGitlint returns the path to a script logging its arguments.
+func fakeGitlint(t *testing.T, log string) string {
+	path := filepath.Join(t.TempDir(), "fake gitlint")
+
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho \"gitlint $*\" >> "+log+"\n"), 0o700)) //nolint:gosec // test script
+
+	return path
+}
+
+func writeHook(t *testing.T, dir, contents string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "commit-msg"), []byte(contents), 0o700)) //nolint:gosec // test hook
+}
+
+func run(t *testing.T, dir, msgFile string) {
+	out, err := exec.Command(filepath.Join(dir, "commit-msg"), msgFile).CombinedOutput() //nolint:gosec // test hook
+	require.NoError(t, err, string(out))
+}
+
+func read(t *testing.T, path string) string {
+	b, err := os.ReadFile(path) //nolint:gosec // test file
+	require.NoError(t, err)
+
+	return string(b)
+}
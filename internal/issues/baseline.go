@@ -0,0 +1,140 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+const baselineHeader = `# Issues ignored by gitlint --baseline, as "<commit> <rule>" lines.
+# Created with gitlint baseline create.
+`
+
+// Baseline is a set of known issues, identified by their commit's hash and
+// their rule, that are ignored so that new rules can be enforced on new
+// commits without rewriting history.
+type Baseline struct {
+	// entries tell whether each known issue was found again.
+	entries map[entry]bool
+}
+
+type entry struct {
+	hash string
+	rule string
+}
+
+// BaselineIn reads a baseline written by WriteBaseline. Blank lines and lines
+// starting with "#" are skipped.
+// It fails if any other line isn't a commit's hash and a rule.
+func BaselineIn(reader io.Reader) (*Baseline, error) {
+	baseline := &Baseline{entries: make(map[entry]bool)}
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, rule, _ := strings.Cut(line, " ")
+		rule = strings.TrimSpace(rule)
+
+		if !isHash(hash) || rule == "" || strings.ContainsAny(rule, " \t") {
+			return nil, fmt.Errorf("invalid baseline entry [%s]: expected <commit> <rule>", line)
+		}
+
+		baseline.entries[entry{strings.ToLower(hash), rule}] = false
+	}
+
+	return baseline, scanner.Err()
+}
+
+// WriteBaseline writes the commit hash and rule of each of the issues to the
+// writer, sorted so that changes to the baseline diff well, and returns how
+// many entries were written. Issues of messages that aren't commits or tags,
+// like those of --msg-file, have no hash and are left out.
+func WriteBaseline(w io.Writer, issues Issues) (int, error) {
+	lines := make([]string, 0)
+
+	for i := range issues {
+		if !isHash(i.Commit.Hash) {
+			continue
+		}
+
+		lines = append(lines, strings.TrimSpace(strings.ToLower(i.Commit.Hash)+" "+i.Rule)+"\n")
+	}
+
+	slices.Sort(lines)
+	lines = slices.Compact(lines)
+
+	if _, err := io.WriteString(w, baselineHeader+strings.Join(lines, "")); err != nil {
+		return 0, err
+	}
+
+	return len(lines), nil
+}
+
+// Baselined returns the issues except those in the baseline, if any.
+func Baselined(baseline *Baseline, issues Issues) Issues {
+	if baseline == nil {
+		return issues
+	}
+
+	return func(yield func(Issue) bool) {
+		for i := range issues {
+			e := entry{strings.ToLower(i.Commit.Hash), i.Rule}
+
+			if _, known := baseline.entries[e]; known {
+				baseline.entries[e] = true
+
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Stale returns the entries of the baseline, as "<commit> <rule>", whose
+// issues Baselined hasn't found again, sorted. They were fixed, or belong to
+// commits that weren't linted.
+func (b *Baseline) Stale() []string {
+	stale := make([]string, 0)
+
+	for e, found := range b.entries {
+		if !found {
+			stale = append(stale, e.hash+" "+e.rule)
+		}
+	}
+
+	slices.Sort(stale)
+
+	return stale
+}
+
+// isHash tells whether s is a SHA-1 or SHA-256 hash in hex.
+func isHash(s string) bool {
+	_, err := hex.DecodeString(s)
+
+	return err == nil && (len(s) == 40 || len(s) == 64)
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+const (
+	legacy = "18045269d8d2fd8f53d5d2ef4c1d8f4d1b1c8f61"
+	recent = "4be918ff8bfc91de77a1f5b6a8d1d2f2ec05f6a2"
+)
+
+func TestBaseline(t *testing.T) {
+	filters := []issues.Filter{
+		issues.Named("subject-maxlen", issues.OfSubjectMaxLength(5)),
+		issues.Named("body-regex", must(t)(issues.OfBodyRegex(`.`))),
+	}
+	legacyCommit := &commits.Commit{Hash: legacy, Message: "Legacy subject"}
+
+	var b bytes.Buffer
+
+	n, err := issues.WriteBaseline(&b, issues.Collected(filters, commits.Commits(slices.Values(
+		[]*commits.Commit{legacyCommit},
+	))))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n, "issues.WriteBaseline() must write an entry per issue")
+	assert.Contains(t, b.String(), legacy+" body-regex\n"+legacy+" subject-maxlen\n",
+		"issues.WriteBaseline() must write the sorted commit hashes and rules of the issues")
+
+	baseline, err := issues.BaselineIn(strings.NewReader(
+		b.String() + "\n" + strings.ToUpper(recent) + " subject-maxlen\n",
+	))
+	require.NoError(t, err)
+
+	found := slices.Collect(iter.Seq[issues.Issue](issues.Baselined(baseline, issues.Collected(
+		filters,
+		commits.Commits(slices.Values([]*commits.Commit{
+			{Hash: legacy, Message: "Legacy subject\n\nNow with a body"},
+			{Hash: recent, Message: "Recent subject"},
+		})),
+	))))
+
+	require.Len(t, found, 1, "issues.Baselined() must drop the issues in the baseline")
+	assert.Equal(t, recent, found[0].Commit.Hash,
+		"issues.Baselined() must keep the issues not in the baseline")
+	assert.Equal(t, "body-regex", found[0].Rule,
+		"issues.Baselined() must keep the issues not in the baseline")
+	assert.Equal(t, []string{legacy + " body-regex"}, baseline.Stale(),
+		"issues.Baseline.Stale() must return the entries that weren't found again")
+}
+
+func TestBaselineInInvalid(t *testing.T) {
+	for _, line := range []string{
+		legacy + " subject-maxlen body-regex",
+		legacy,
+		"abc123",
+		"not-a-hash subject-maxlen",
+		"abc123 subject-maxlen",
+	} {
+		_, err := issues.BaselineIn(strings.NewReader(line + "\n"))
+		assert.Error(t, err, "issues.BaselineIn() must fail on malformed entries: %q", line)
+	}
+}
+
+func TestWriteBaselineSkipsMessages(t *testing.T) {
+	var b bytes.Buffer
+
+	n, err := issues.WriteBaseline(&b, issues.Collected(
+		[]issues.Filter{issues.Named("subject-maxlen", issues.OfSubjectMaxLength(5))},
+		commits.Commits(slices.Values([]*commits.Commit{
+			{Hash: "COMMIT_EDITMSG", Message: "Long subject"},
+		})),
+	))
+	require.NoError(t, err)
+	assert.Zero(t, n, "issues.WriteBaseline() must leave out the issues of messages without a hash")
+}
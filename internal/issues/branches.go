@@ -0,0 +1,62 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+// OfBranchNameRegex tests the names of branches with the regex, like
+// "^(feat|fix)/[A-Z]+-\d+-". Commits are not branches and pass.
+// It fails if the regex is invalid.
+func OfBranchNameRegex(regex string) (Filter, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid branch name regex [%s]: %w", regex, err)
+	}
+
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if c.Branch != "" && !re.MatchString(c.Branch) {
+			issue = Issue{
+				Desc:   fmt.Sprintf("branch name does not match regex [%s]", regex),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}, nil
+}
+
+// OfBranchNameMaxLength checks that the names of branches are no longer than
+// max characters. Commits are not branches and pass.
+func OfBranchNameMaxLength(max int) Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if len(c.Branch) > max {
+			issue = Issue{
+				Desc:   fmt.Sprintf("branch name length exceeds max [%d]", max),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+func TestOfBranchNameRegex(t *testing.T) {
+	const regex = `^(feat|fix)/[A-Z]+-\d+-`
+
+	filter := must(t)(issues.OfBranchNameRegex(regex))
+
+	assert.Empty(t, filter(&commits.Commit{Branch: "feat/ABC-12-login"}).Desc,
+		"issues.OfBranchNameRegex() must pass branches whose names match the regex")
+	assert.Equal(t, "branch name does not match regex ["+regex+"]",
+		filter(&commits.Commit{Branch: "my-stuff"}).Desc,
+		"issues.OfBranchNameRegex() must report branches whose names don't match the regex")
+	assert.Empty(t, filter(&commits.Commit{Message: "my-stuff"}).Desc,
+		"issues.OfBranchNameRegex() must pass commits")
+
+	_, err := issues.OfBranchNameRegex("[")
+	assert.Error(t, err, "issues.OfBranchNameRegex() must fail on invalid regexes")
+}
+
+func TestOfBranchNameMaxLength(t *testing.T) {
+	filter := issues.OfBranchNameMaxLength(10)
+
+	assert.Empty(t, filter(&commits.Commit{Branch: "feat/ABC-1"}).Desc,
+		"issues.OfBranchNameMaxLength() must pass branches with names up to the max length")
+	assert.Equal(t, "branch name length exceeds max [10]",
+		filter(&commits.Commit{Branch: "feat/ABC-12"}).Desc,
+		"issues.OfBranchNameMaxLength() must report branches with names longer than the max length")
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+const (
+	cachePrefix = "cache-"
+	cacheSuffix = ".json"
+)
+
+// Cache records the results of filters by commit hash so that they can be
+// reused across runs. Commits are immutable, so results remain valid for as
+// long as the configuration of the filters doesn't change.
+type Cache struct {
+	dir  string
+	key  string
+	once sync.Once
+	mu   sync.Mutex
+	// results maps commit hashes to the issue descriptions found by each
+	// filter, indexed by the filter's position. Empty descriptions mean no
+	// issue was found.
+	results map[string]map[int]string
+	dirty   bool
+}
+
+// CacheIn returns the cache stored in the directory for the configuration
+// identified by key. Results cached for any other configuration are
+// discarded when this cache is saved.
+func CacheIn(dir, key string) *Cache {
+	return &Cache{dir: dir, key: key}
+}
+
+// Cached returns the filters with their results looked up in, and recorded
+// to, the cache. The filters must always be given in the same order for a
+// given cache key.
+func Cached(cache *Cache, filters []Filter) []Filter {
+	cached := make([]Filter, 0, len(filters))
+
+	for idx, f := range filters {
+		cached = append(cached, func(c *commits.Commit) Issue {
+			if desc, found := cache.lookup(c.Hash, idx); found {
+				var issue Issue
+
+				if desc != "" {
+					issue = Issue{Desc: desc, Commit: *c}
+				}
+
+				return issue
+			}
+
+			issue := f(c)
+			cache.record(c.Hash, idx, issue.Desc)
+
+			return issue
+		})
+	}
+
+	return cached
+}
+
+// Save writes the cache to disk if new results were recorded, removing the
+// caches of other configurations.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+
+	stale, err := filepath.Glob(filepath.Join(c.dir, cachePrefix+"*"+cacheSuffix))
+	if err != nil {
+		return err
+	}
+
+	for _, s := range stale {
+		if s != c.file() {
+			if err = os.Remove(s); err != nil {
+				return err
+			}
+		}
+	}
+
+	b, err := json.Marshal(c.results)
+	if err != nil {
+		return err
+	}
+
+	tmp := c.file() + ".tmp"
+
+	if err = os.WriteFile(tmp, b, 0o644); err != nil { //nolint:gosec // the cache is not sensitive
+		return err
+	}
+
+	c.dirty = false
+
+	return os.Rename(tmp, c.file())
+}
+
+func (c *Cache) file() string {
+	return filepath.Join(c.dir, cachePrefix+c.key+cacheSuffix)
+}
+
+// load reads the cache from disk the first time it's needed. A missing or
+// corrupt cache is treated as empty.
+func (c *Cache) load() {
+	c.once.Do(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.results = make(map[string]map[int]string)
+
+		b, err := os.ReadFile(c.file())
+		if errors.Is(err, os.ErrNotExist) {
+			return
+		}
+
+		if err != nil || json.Unmarshal(b, &c.results) != nil {
+			c.results = make(map[string]map[int]string)
+		}
+	})
+}
+
+func (c *Cache) lookup(hash string, filter int) (desc string, found bool) {
+	c.load()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	desc, found = c.results[strings.ToLower(hash)][filter]
+
+	return desc, found
+}
+
+func (c *Cache) record(hash string, filter int, desc string) {
+	c.load()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hash = strings.ToLower(hash)
+
+	if c.results[hash] == nil {
+		c.results[hash] = make(map[int]string)
+	}
+
+	c.results[hash][filter] = desc
+	c.dirty = true
+}
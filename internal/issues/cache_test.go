@@ -0,0 +1,105 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+func TestCachedReusesResults(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	filters := []issues.Filter{
+		func(c *commits.Commit) issues.Issue {
+			calls++
+
+			return issues.Issue{Desc: "test", Commit: *c}
+		},
+		func(c *commits.Commit) issues.Issue {
+			calls++
+
+			return issues.Issue{}
+		},
+	}
+	commit := &commits.Commit{Hash: "18045269d8d2fd8f53d01883c6c7b548d0b9e3ae"}
+
+	cache := issues.CacheIn(dir, "key")
+	cached := issues.Cached(cache, filters)
+	assert.Equal(t, "test", cached[0](commit).Desc)
+	assert.Zero(t, cached[1](commit))
+	require.NoError(t, cache.Save())
+
+	cached = issues.Cached(issues.CacheIn(dir, "key"), filters)
+	assert.Equal(t, issues.Issue{Desc: "test", Commit: *commit}, cached[0](commit),
+		"issues.Cached() must return the cached issues")
+	assert.Zero(t, cached[1](commit),
+		"issues.Cached() must remember that no issue was found")
+	assert.Equal(t, 2, calls,
+		"issues.Cached() must not run the filters again for cached commits")
+}
+
+func TestCacheInvalidatedByKey(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	filters := []issues.Filter{
+		func(c *commits.Commit) issues.Issue {
+			calls++
+
+			return issues.Issue{}
+		},
+	}
+	commit := &commits.Commit{Hash: "18045269d8d2fd8f53d01883c6c7b548d0b9e3ae"}
+
+	old := issues.CacheIn(dir, "old")
+	issues.Cached(old, filters)[0](commit)
+	require.NoError(t, old.Save())
+
+	current := issues.CacheIn(dir, "new")
+	issues.Cached(current, filters)[0](commit)
+	require.NoError(t, current.Save())
+
+	assert.Equal(t, 2, calls,
+		"issues.Cached() must not reuse results cached with another key")
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "cache-new.json")}, files,
+		"Cache.Save() must remove the caches of other keys")
+}
+
+func TestCacheCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cache-key.json"), []byte("{corrupt"), 0600))
+
+	issue := issues.Cached(
+		issues.CacheIn(dir, "key"),
+		[]issues.Filter{
+			func(c *commits.Commit) issues.Issue {
+				return issues.Issue{Desc: "test", Commit: *c}
+			},
+		},
+	)[0](&commits.Commit{Hash: "18045269d8d2fd8f53d01883c6c7b548d0b9e3ae"})
+
+	assert.Equal(t, "test", issue.Desc,
+		"issues.Cached() must ignore corrupt caches")
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+const dateFormat = time.RFC3339
+
+// OfFutureDates checks that a commit's author and commit dates are not later
+// than now by more than the tolerance.
+func OfFutureDates(now time.Time, tolerance time.Duration) Filter {
+	limit := now.Add(tolerance)
+
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		for _, d := range dates(c) {
+			if d.After(limit) {
+				issue = Issue{
+					Desc:   fmt.Sprintf("date [%s] is in the future", d.Format(dateFormat)),
+					Commit: *c,
+				}
+
+				break
+			}
+		}
+
+		return issue
+	}
+}
+
+// OfDatesBeforeRoots checks that a commit's author and commit dates are not
+// earlier than the earliest author or commit date of the roots, which are
+// not checked against themselves.
+// The roots are only fetched once, the first time a commit is checked.
+func OfDatesBeforeRoots(roots commits.Commits) Filter {
+	type origin struct {
+		date   time.Time
+		hashes map[string]bool
+	}
+
+	earliest := sync.OnceValue(func() *origin {
+		first := &origin{hashes: make(map[string]bool)}
+
+		for r := range roots {
+			first.hashes[r.Hash] = true
+
+			for _, d := range dates(r) {
+				if first.date.IsZero() || d.Before(first.date) {
+					first.date = d
+				}
+			}
+		}
+
+		return first
+	})
+
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		root := earliest()
+		if c.Hash != "" && root.hashes[c.Hash] {
+			return issue
+		}
+
+		for _, d := range dates(c) {
+			if d.Before(root.date) {
+				issue = Issue{
+					Desc: fmt.Sprintf(
+						"date [%s] is before the root commit [%s]",
+						d.Format(dateFormat), root.date.Format(dateFormat),
+					),
+					Commit: *c,
+				}
+
+				break
+			}
+		}
+
+		return issue
+	}
+}
+
+// OfAuthorDateSkew checks that a commit's author date is not later than its
+// commit date by more than the tolerance.
+func OfAuthorDateSkew(tolerance time.Duration) Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if !c.CommitDate.IsZero() && c.Date.Sub(c.CommitDate) > tolerance {
+			issue = Issue{
+				Desc: fmt.Sprintf(
+					"author date [%s] is after commit date [%s]",
+					c.Date.Format(dateFormat), c.CommitDate.Format(dateFormat),
+				),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}
+}
+
+// OfDatesBeforeParents checks that a commit's commit date is not earlier than
+// the commit dates of its parents.
+func OfDatesBeforeParents() Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if c.CommitDate.IsZero() || c.ParentDate == nil {
+			return issue
+		}
+
+		if parent := c.ParentDate(); c.CommitDate.Before(parent) {
+			issue = Issue{
+				Desc: fmt.Sprintf(
+					"commit date [%s] is before its parent's [%s]",
+					c.CommitDate.Format(dateFormat), parent.Format(dateFormat),
+				),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}
+}
+
+// dates are the commit's known author and commit dates.
+func dates(c *commits.Commit) []time.Time {
+	known := make([]time.Time, 0, 2)
+
+	for _, d := range []time.Time{c.Date, c.CommitDate} {
+		if !d.IsZero() {
+			known = append(known, d)
+		}
+	}
+
+	return known
+}
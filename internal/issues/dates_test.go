@@ -0,0 +1,146 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+func TestOfFutureDatesMatch(t *testing.T) {
+	now := time.Now()
+
+	assert.NotZero(t,
+		issues.OfFutureDates(now, time.Hour)(
+			&commits.Commit{Date: now, CommitDate: now.Add(2 * time.Hour)},
+		),
+		"filter.OfFutureDates() must match if a date is too far in the future",
+	)
+}
+
+func TestOfFutureDatesNonMatch(t *testing.T) {
+	now := time.Now()
+
+	assert.Zero(t,
+		issues.OfFutureDates(now, time.Hour)(
+			&commits.Commit{Date: now.Add(-time.Hour), CommitDate: now.Add(30 * time.Minute)},
+		),
+		"filter.OfFutureDates() must not match if dates are within the tolerance",
+	)
+}
+
+func TestOfDatesBeforeRootsMatch(t *testing.T) {
+	root := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NotZero(t,
+		issues.OfDatesBeforeRoots(roots(root))(
+			&commits.Commit{Date: time.Unix(0, 0), CommitDate: root.Add(time.Hour)},
+		),
+		"filter.OfDatesBeforeRoots() must match if a date is before the root commit",
+	)
+}
+
+func TestOfDatesBeforeRootsNonMatch(t *testing.T) {
+	root := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Zero(t,
+		issues.OfDatesBeforeRoots(roots(root.Add(time.Hour), root))(
+			&commits.Commit{Date: root, CommitDate: root},
+		),
+		"filter.OfDatesBeforeRoots() must not match if dates are not before the earliest root",
+	)
+}
+
+func TestOfDatesBeforeRootsRewritten(t *testing.T) {
+	authored := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	committed := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	root := &commits.Commit{Hash: "root", Date: authored, CommitDate: committed}
+	filter := issues.OfDatesBeforeRoots(commits.Commits(slices.Values([]*commits.Commit{root})))
+
+	assert.Zero(t, filter(root),
+		"filter.OfDatesBeforeRoots() must not check the roots against themselves")
+	assert.Zero(t,
+		filter(&commits.Commit{Date: authored.Add(time.Hour), CommitDate: committed}),
+		"filter.OfDatesBeforeRoots() must compare against the roots' author dates too",
+	)
+	assert.NotZero(t,
+		filter(&commits.Commit{Date: authored.Add(-time.Hour), CommitDate: committed}),
+		"filter.OfDatesBeforeRoots() must match if a date is before the root's author date",
+	)
+}
+
+func TestOfAuthorDateSkewMatch(t *testing.T) {
+	now := time.Now()
+
+	assert.NotZero(t,
+		issues.OfAuthorDateSkew(time.Hour)(
+			&commits.Commit{Date: now.Add(24 * time.Hour), CommitDate: now},
+		),
+		"filter.OfAuthorDateSkew() must match if the author date is far after the commit date",
+	)
+}
+
+func TestOfAuthorDateSkewNonMatch(t *testing.T) {
+	now := time.Now()
+
+	assert.Zero(t,
+		issues.OfAuthorDateSkew(time.Hour)(
+			&commits.Commit{Date: now.Add(-24 * time.Hour), CommitDate: now},
+		),
+		"filter.OfAuthorDateSkew() must not match if the author date is before the commit date",
+	)
+}
+
+func TestOfDatesBeforeParentsMatch(t *testing.T) {
+	now := time.Now()
+
+	assert.NotZero(t,
+		issues.OfDatesBeforeParents()(
+			&commits.Commit{CommitDate: now, ParentDate: dated(now.Add(time.Minute))},
+		),
+		"filter.OfDatesBeforeParents() must match if the commit is dated before its parent",
+	)
+}
+
+func TestOfDatesBeforeParentsNonMatch(t *testing.T) {
+	now := time.Now()
+
+	assert.Zero(t,
+		issues.OfDatesBeforeParents()(
+			&commits.Commit{CommitDate: now, ParentDate: dated(now.Add(-time.Minute))},
+		),
+		"filter.OfDatesBeforeParents() must not match if the commit is dated after its parent",
+	)
+}
+
+func dated(t time.Time) commits.ParentDate {
+	return func() time.Time { return t }
+}
+
+func roots(dates ...time.Time) commits.Commits {
+	return func(yield func(*commits.Commit) bool) {
+		for _, d := range dates {
+			if !yield(&commits.Commit{CommitDate: d}) {
+				return
+			}
+		}
+	}
+}
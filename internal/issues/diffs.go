@@ -0,0 +1,88 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+// OfBodyForLargeChanges checks that commits changing more than maxFiles files
+// or more than maxLines lines have a body of at least minLength characters.
+// A negative maxFiles or maxLines disables that threshold. Commits without
+// changes are not checked.
+func OfBodyForLargeChanges(maxFiles, maxLines, minLength int) Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if maxFiles < 0 && maxLines < 0 || c.Changes == nil ||
+			len(strings.TrimSpace(c.Body())) >= minLength {
+			return issue
+		}
+
+		if maxFiles >= 0 {
+			if files := len(c.Changes()); files > maxFiles {
+				return Issue{
+					Desc: fmt.Sprintf(
+						"body shorter than [%d] for a change of [%d] files", minLength, files,
+					),
+					Commit: *c,
+				}
+			}
+		}
+
+		// lines are only counted if needed since it requires diffing the files
+		if maxLines < 0 {
+			return issue
+		}
+
+		if lines := linesChanged(c); lines > maxLines {
+			issue = Issue{
+				Desc:   fmt.Sprintf("body shorter than [%d] for a change of [%d] lines", minLength, lines),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}
+}
+
+// OfMaxFiles checks that a commit changes at most max files.
+func OfMaxFiles(max int) Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if c.Changes != nil && len(c.Changes()) > max {
+			issue = Issue{
+				Desc:   fmt.Sprintf("number of changed files exceeds max [%d]", max),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}
+}
+
+func linesChanged(c *commits.Commit) int {
+	lines := 0
+
+	for _, s := range c.Stats() {
+		lines += s.Additions + s.Deletions
+	}
+
+	return lines
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+func TestOfBodyForLargeChanges(t *testing.T) {
+	for _, test := range []struct {
+		message  string
+		files    int
+		lines    int
+		expected string
+	}{
+		{"small change", 1, 10, ""},
+		{"many lines", 1, 2000, "body shorter than [20] for a change of [2000] lines"},
+		{"many files", 6, 10, "body shorter than [20] for a change of [6] files"},
+		{"short body\n\ntoo short", 1, 2000, "body shorter than [20] for a change of [2000] lines"},
+		{"long body\n\nexplains the whole change", 6, 2000, ""},
+	} {
+		assert.Equal(t, test.expected,
+			issues.OfBodyForLargeChanges(5, 500, 20)(stats(test.message, test.files, test.lines)).Desc,
+			"filter.OfBodyForLargeChanges() must require a body for large changes: %q", test.message,
+		)
+	}
+}
+
+func TestOfBodyForLargeChangesDisabled(t *testing.T) {
+	c := stats("huge change", 100, 100000)
+	c.Changes = func() []*commits.Change { panic("files must not be diffed") }
+	c.Stats = func() []*commits.Stat { panic("lines must not be counted") }
+
+	assert.Zero(t,
+		issues.OfBodyForLargeChanges(-1, -1, 1)(c),
+		"filter.OfBodyForLargeChanges() must not check disabled thresholds",
+	)
+}
+
+func TestOfMaxFiles(t *testing.T) {
+	assert.Zero(t,
+		issues.OfMaxFiles(2)(stats("two files", 2, 10)),
+		"filter.OfMaxFiles() must not match commits changing up to max files",
+	)
+	assert.Equal(t,
+		"number of changed files exceeds max [2]",
+		issues.OfMaxFiles(2)(stats("three files", 3, 10)).Desc,
+		"filter.OfMaxFiles() must match commits changing more than max files",
+	)
+}
+
+// stats returns a commit changing this many files, with the lines spread
+// evenly between additions and deletions of the first file.
+func stats(message string, files, lines int) *commits.Commit {
+	changes := make([]*commits.Change, 0, files)
+
+	for range files {
+		changes = append(changes, &commits.Change{Path: "file"})
+	}
+
+	return &commits.Commit{
+		Message: message,
+		Changes: func() []*commits.Change { return changes },
+		Stats: func() []*commits.Stat {
+			return []*commits.Stat{{Path: "file", Additions: lines / 2, Deletions: lines - lines/2}}
+		},
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+// OfLargeFiles checks that the files added or modified by a commit are no
+// larger than max bytes.
+func OfLargeFiles(max int64) Filter {
+	return ofFiles(
+		fmt.Sprintf("files larger than [%d] bytes", max),
+		func(c *commits.Change) bool {
+			return c.File().Size > max
+		},
+	)
+}
+
+// OfBinaryFiles checks that the files added or modified by a commit are not
+// binary, unless selected by the allowed pathspec.
+func OfBinaryFiles(allowed commits.Pathspec) Filter {
+	return ofFiles(
+		"binary files",
+		func(c *commits.Change) bool {
+			return !allowed(c.Path) && c.File().Binary
+		},
+	)
+}
+
+// OfForbiddenFiles checks that a commit doesn't add or modify files selected
+// by the forbidden pathspec. Deleting them is fine.
+func OfForbiddenFiles(forbidden commits.Pathspec) Filter {
+	return ofFiles(
+		"forbidden files",
+		func(c *commits.Change) bool {
+			return forbidden(c.Path)
+		},
+	)
+}
+
+// ofFiles reports the files added or modified by a commit for which the
+// predicate is true.
+func ofFiles(desc string, predicate func(*commits.Change) bool) Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if c.Changes == nil {
+			return issue
+		}
+
+		paths := make([]string, 0)
+
+		for _, change := range c.Changes() {
+			if !change.Deleted && predicate(change) {
+				paths = append(paths, change.Path)
+			}
+		}
+
+		if len(paths) > 0 {
+			issue = Issue{
+				Desc:   fmt.Sprintf("%s [%s]", desc, strings.Join(paths, ", ")),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+func TestOfLargeFiles(t *testing.T) {
+	c := files(
+		&commits.Change{Path: "small.txt", File: file(10, false)},
+		&commits.Change{Path: "dist/app.js", File: file(5000, false)},
+		&commits.Change{Path: "deleted.zip", Deleted: true},
+	)
+
+	assert.Equal(t,
+		"files larger than [1024] bytes [dist/app.js]",
+		issues.OfLargeFiles(1024)(c).Desc,
+		"filter.OfLargeFiles() must report the files added or modified over the max size",
+	)
+	assert.Zero(t,
+		issues.OfLargeFiles(5000)(c),
+		"filter.OfLargeFiles() must not match files up to the max size",
+	)
+}
+
+func TestOfBinaryFiles(t *testing.T) {
+	c := files(
+		&commits.Change{Path: "docs/logo.png", File: file(10, true)},
+		&commits.Change{Path: "build/app", File: file(10, true)},
+		&commits.Change{Path: "main.go", File: file(10, false)},
+	)
+
+	assert.Equal(t,
+		"binary files [build/app]",
+		issues.OfBinaryFiles(commits.PathsOf([]string{"*.png"}))(c).Desc,
+		"filter.OfBinaryFiles() must report binary files that aren't allowed",
+	)
+}
+
+func TestOfForbiddenFiles(t *testing.T) {
+	c := files(
+		&commits.Change{Path: "certs/server.pem", File: file(10, false)},
+		&commits.Change{Path: "web/node_modules/left-pad/index.js", File: file(10, false)},
+		&commits.Change{Path: ".env", Deleted: true},
+		&commits.Change{Path: "main.go", File: file(10, false)},
+	)
+
+	assert.Equal(t,
+		"forbidden files [certs/server.pem, web/node_modules/left-pad/index.js]",
+		issues.OfForbiddenFiles(commits.PathsOf([]string{"*.pem", "node_modules", ".env"}))(c).Desc,
+		"filter.OfForbiddenFiles() must report forbidden files added or modified",
+	)
+}
+
+func TestOfFilesWithoutChanges(t *testing.T) {
+	assert.Zero(t,
+		issues.OfForbiddenFiles(commits.PathsOf([]string{"**"}))(
+			&commits.Commit{Message: "not in a repo"},
+		),
+		"filter.OfForbiddenFiles() must not check commits without changes",
+	)
+}
+
+func files(changes ...*commits.Change) *commits.Commit {
+	return &commits.Commit{
+		Message: "subject",
+		Changes: func() []*commits.Change { return changes },
+	}
+}
+
+func file(size int64, binary bool) func() *commits.File {
+	return func() *commits.File {
+		return &commits.File{Size: size, Binary: binary}
+	}
+}
@@ -17,6 +17,7 @@ package issues
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/llorllale/go-gitlint/internal/commits"
 )
@@ -27,16 +28,17 @@ import (
 type Filter func(*commits.Commit) Issue
 
 // OfSubjectRegex tests a commit's subject with the regex.
-func OfSubjectRegex(regex string) Filter {
+// It fails if the regex is invalid.
+func OfSubjectRegex(regex string) (Filter, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject regex [%s]: %w", regex, err)
+	}
+
 	return func(c *commits.Commit) Issue {
 		var issue Issue
 
-		matched, err := regexp.MatchString(regex, c.Subject())
-		if err != nil {
-			panic(err)
-		}
-
-		if !matched {
+		if !re.MatchString(c.Subject()) {
 			issue = Issue{
 				Desc:   fmt.Sprintf("subject does not match regex [%s]", regex),
 				Commit: *c,
@@ -44,20 +46,21 @@ func OfSubjectRegex(regex string) Filter {
 		}
 
 		return issue
-	}
+	}, nil
 }
 
 // OfBodyRegex tests a commit's body with the regex.
-func OfBodyRegex(regex string) Filter {
+// It fails if the regex is invalid.
+func OfBodyRegex(regex string) (Filter, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body regex [%s]: %w", regex, err)
+	}
+
 	return func(c *commits.Commit) Issue {
 		var issue Issue
 
-		matched, err := regexp.MatchString(regex, c.Body())
-		if err != nil {
-			panic(err)
-		}
-
-		if !matched {
+		if !re.MatchString(c.Body()) {
 			issue = Issue{
 				Desc:   fmt.Sprintf("body does not conform to regex [%s]", regex),
 				Commit: *c,
@@ -65,7 +68,7 @@ func OfBodyRegex(regex string) Filter {
 		}
 
 		return issue
-	}
+	}, nil
 }
 
 // OfSubjectMaxLength checks that a commit's subject does not exceed this length.
@@ -117,3 +120,60 @@ func OfBodyMaxLength(max int) Filter {
 		return issue
 	}
 }
+
+// OfCanonicalIdentity checks that a commit's author and committer identities
+// are known to the repository's .mailmap. It is meant to be used on commits
+// that went through commits.Mailmapped.
+func OfCanonicalIdentity() Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		for _, a := range []*commits.Author{c.Author, c.Committer} {
+			if a != nil && !a.Canonical {
+				issue = Issue{
+					Desc:   fmt.Sprintf("identity [%s <%s>] is not in the mailmap", a.Name, a.Email),
+					Commit: *c,
+				}
+
+				break
+			}
+		}
+
+		return issue
+	}
+}
+
+// OfRequiredTrailers checks that a commit's message has trailers with each of
+// these keys, which are compared case-insensitively.
+func OfRequiredTrailers(keys []string) Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		missing := make([]string, 0)
+
+		for _, k := range keys {
+			if k != "" && !hasTrailer(c, k) {
+				missing = append(missing, k)
+			}
+		}
+
+		if len(missing) > 0 {
+			issue = Issue{
+				Desc:   fmt.Sprintf("missing trailers [%s]", strings.Join(missing, ", ")),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}
+}
+
+func hasTrailer(c *commits.Commit, key string) bool {
+	for _, t := range c.Trailers() {
+		if strings.EqualFold(t.Key, key) {
+			return true
+		}
+	}
+
+	return false
+}
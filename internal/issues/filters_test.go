@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/llorllale/go-gitlint/internal/commits"
 	"github.com/llorllale/go-gitlint/internal/issues"
@@ -26,7 +27,7 @@ import (
 
 func TestOfSubjectRegexMatch(t *testing.T) {
 	assert.Zero(t,
-		issues.OfSubjectRegex(`\(#\d+\) [\w ]{10,50}`)(
+		must(t)(issues.OfSubjectRegex(`\(#\d+\) [\w ]{10,50}`))(
 			&commits.Commit{
 				Message: "(#123) This is a good subject",
 			},
@@ -37,7 +38,7 @@ func TestOfSubjectRegexMatch(t *testing.T) {
 
 func TestOfSubjectRegexNonMatch(t *testing.T) {
 	assert.NotZero(t,
-		issues.OfSubjectRegex(`\(#\d+\) [\w ]{,50}`)(
+		must(t)(issues.OfSubjectRegex(`\(#\d+\) [\w ]{,50}`))(
 			&commits.Commit{
 				Message: "I break all the rules!",
 			},
@@ -48,7 +49,7 @@ func TestOfSubjectRegexNonMatch(t *testing.T) {
 
 func TestOfBodyRegexMatch(t *testing.T) {
 	assert.Zero(t,
-		issues.OfBodyRegex(`^.{10,20}$`)(
+		must(t)(issues.OfBodyRegex(`^.{10,20}$`))(
 			&commits.Commit{
 				Message: "subject\n\nBetween 10 and 20",
 			},
@@ -59,7 +60,7 @@ func TestOfBodyRegexMatch(t *testing.T) {
 
 func TestOfBodyRegexNonMatch(t *testing.T) {
 	assert.NotZero(t,
-		issues.OfBodyRegex(`^.{10,20}$`)(
+		must(t)(issues.OfBodyRegex(`^.{10,20}$`))(
 			&commits.Commit{
 				Message: "subject\n\nMore than twenty characters!",
 			},
@@ -131,3 +132,67 @@ func TestOfBodyMaxLengthNonMatch(t *testing.T) {
 		),
 	)
 }
+
+func TestOfCanonicalIdentityMatch(t *testing.T) {
+	assert.NotZero(t,
+		issues.OfCanonicalIdentity()(
+			&commits.Commit{
+				Author:    &commits.Author{Name: "John", Email: "john@test.com", Canonical: true},
+				Committer: &commits.Author{Name: "Jane", Email: "jane@test.com"},
+			},
+		),
+		"filter.OfCanonicalIdentity() must match if an identity is not in the mailmap",
+	)
+}
+
+func TestOfCanonicalIdentityNonMatch(t *testing.T) {
+	assert.Zero(t,
+		issues.OfCanonicalIdentity()(
+			&commits.Commit{
+				Author:    &commits.Author{Name: "John", Email: "john@test.com", Canonical: true},
+				Committer: &commits.Author{Name: "Jane", Email: "jane@test.com", Canonical: true},
+			},
+		),
+		"filter.OfCanonicalIdentity() must not match if all identities are in the mailmap",
+	)
+}
+
+func TestOfRequiredTrailers(t *testing.T) {
+	assert.Zero(t,
+		issues.OfRequiredTrailers([]string{"change-ticket", ""})(
+			&commits.Commit{Message: "subject\n\nChange-Ticket: OPS-42"},
+		),
+		"filter.OfRequiredTrailers() must not match if the trailers are present, regardless of case",
+	)
+}
+
+func TestOfRequiredTrailersMissing(t *testing.T) {
+	assert.Equal(t,
+		"missing trailers [Change-Ticket, Signed-off-by]",
+		issues.OfRequiredTrailers([]string{"Change-Ticket", "Signed-off-by"})(
+			&commits.Commit{Message: "subject\n\nChange-Ticket OPS-42"},
+		).Desc,
+		"filter.OfRequiredTrailers() must report the missing trailers",
+	)
+}
+
+func TestOfSubjectRegexInvalid(t *testing.T) {
+	_, err := issues.OfSubjectRegex(`(`)
+	assert.Error(t, err,
+		"filter.OfSubjectRegex() must fail if the regex is invalid")
+}
+
+func TestOfBodyRegexInvalid(t *testing.T) {
+	_, err := issues.OfBodyRegex(`[`)
+	assert.Error(t, err,
+		"filter.OfBodyRegex() must fail if the regex is invalid")
+}
+
+// must fails the test if the filter could not be created.
+func must(t *testing.T) func(issues.Filter, error) issues.Filter {
+	return func(f issues.Filter, err error) issues.Filter {
+		require.NoError(t, err)
+
+		return f
+	}
+}
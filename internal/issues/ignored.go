@@ -0,0 +1,88 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+// IgnoreTrailer is the key of the trailers naming the rules that a commit is
+// exempt from, as in "Gitlint-Ignore: subject-maxlen, body-regex".
+const IgnoreTrailer = "Gitlint-Ignore"
+
+// Named returns the filter with the issues it finds attributed to the rule.
+func Named(rule string, filter Filter) Filter {
+	return func(c *commits.Commit) Issue {
+		issue := filter(c)
+		if issue.Desc != "" {
+			issue.Rule = rule
+		}
+
+		return issue
+	}
+}
+
+// Ignored returns the issues except those found by rules that their commit
+// names in Gitlint-Ignore trailers. Rules compare case-insensitively and the
+// disallowed ones can't be ignored. Ignored issues are written to the report,
+// if any, so that exemptions can be audited.
+func Ignored(disallowed []string, report io.Writer, issues Issues) Issues {
+	return func(yield func(Issue) bool) {
+		for i := range issues {
+			if ignores(&i.Commit, i.Rule) && !containsFold(disallowed, i.Rule) {
+				if report != nil {
+					_, err := fmt.Fprintf(report, "%s: ignored [%s] %s\n", i.Commit.ShortID(), i.Rule, i.Desc)
+					if err != nil {
+						panic(err)
+					}
+				}
+
+				continue
+			}
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func ignores(c *commits.Commit, rule string) bool {
+	if rule == "" {
+		return false
+	}
+
+	for _, t := range c.Trailers() {
+		if strings.EqualFold(t.Key, IgnoreTrailer) && containsFold(strings.Split(t.Value, ","), rule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsFold(strs []string, s string) bool {
+	for _, str := range strs {
+		if strings.EqualFold(strings.TrimSpace(str), s) {
+			return true
+		}
+	}
+
+	return false
+}
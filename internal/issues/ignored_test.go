@@ -0,0 +1,67 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+func TestIgnored(t *testing.T) {
+	const hash = "18045269d8d2fd8f53d5d2ef4c1d8f4d1b1c8f61"
+
+	filters := []issues.Filter{
+		issues.Named("subject-maxlen", issues.OfSubjectMaxLength(5)),
+		issues.Named("body-maxlen", issues.OfBodyMaxLength(5)),
+		issues.Named("require-trailers", issues.OfRequiredTrailers([]string{"Change-Id"})),
+	}
+	cmts := commits.Commits(slices.Values([]*commits.Commit{{
+		Hash: hash,
+		Message: "Vendored upstream subject\n\nLong vendored body.\n\n" +
+			"gitlint-ignore: Subject-Maxlen, body-maxlen\nGitlint-Ignore: require-trailers",
+	}}))
+	report := &bytes.Buffer{}
+
+	found := slices.Collect(iter.Seq[issues.Issue](
+		issues.Ignored([]string{"body-maxlen"}, report, issues.Collected(filters, cmts)),
+	))
+
+	assert.Len(t, found, 1,
+		"issues.Ignored() must drop the issues of the rules ignored by the commit")
+	assert.Equal(t, "body-maxlen", found[0].Rule,
+		"issues.Ignored() must keep the issues of disallowed rules")
+	assert.Equal(t,
+		"1804526: ignored [subject-maxlen] subject length exceeds max [5]\n"+
+			"1804526: ignored [require-trailers] missing trailers [Change-Id]\n",
+		report.String(),
+		"issues.Ignored() must report the ignored issues",
+	)
+}
+
+func TestNamed(t *testing.T) {
+	filter := issues.Named("subject-maxlen", issues.OfSubjectMaxLength(5))
+
+	assert.Equal(t, "subject-maxlen", filter(&commits.Commit{Message: "Too long"}).Rule,
+		"issues.Named() must attribute issues to the rule")
+	assert.Zero(t, filter(&commits.Commit{Message: "Ok"}),
+		"issues.Named() must not attribute anything if there is no issue")
+}
@@ -17,6 +17,8 @@ package issues
 
 import (
 	"io"
+	"iter"
+	"sync"
 
 	"github.com/fatih/color"
 	"github.com/llorllale/go-gitlint/internal/commits"
@@ -26,36 +28,160 @@ import (
 type Issue struct {
 	Desc   string
 	Commit commits.Commit
+	// Rule is the name of the rule that found the issue, if named.
+	Rule string
 }
 
-// Issues is a collection of Issues.
-type Issues func() []Issue
+// Issues is a stream of issues, identified lazily as they are iterated.
+type Issues iter.Seq[Issue]
 
-// Collected returns a collection of issues identified.
+// Collected returns the issues identified by the filters in the commits.
 func Collected(filters []Filter, cmts commits.Commits) Issues {
-	return func() []Issue {
-		issues := make([]Issue, 0)
+	return func(yield func(Issue) bool) {
+		for c := range cmts {
+			for _, issue := range evaluated(filters, c) {
+				if !yield(issue) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// CollectedConcurrently returns the issues identified by the filters in the
+// commits, evaluating up to jobs commits at a time. Issues are returned in
+// the same order as Collected would return them.
+func CollectedConcurrently(jobs int, filters []Filter, cmts commits.Commits) Issues {
+	if jobs < 2 {
+		return Collected(filters, cmts)
+	}
+
+	return func(yield func(Issue) bool) {
+		// at most window commits are fetched but not yet yielded, which bounds
+		// both memory and how far ahead of a slow commit the workers can go.
+		window := jobs * 4
+		tokens := make(chan struct{}, window)
+		work := make(chan numbered[*commits.Commit])
+		results := make(chan numbered[[]Issue], window)
+		done := make(chan struct{})
+
+		var wg sync.WaitGroup
+
+		defer func() {
+			close(done)
+			wg.Wait()
+		}()
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			produce(cmts, tokens, work, done)
+		}()
 
-		for _, c := range cmts() {
-			for _, f := range filters {
-				if issue := f(c); issue != (Issue{}) {
-					issues = append(issues, issue)
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			evaluate(jobs, filters, work, results)
+		}()
+
+		pending := make(map[int][]Issue)
+		next := 0
+
+		for r := range results {
+			pending[r.seq] = r.value
+
+			for issues, ok := pending[next]; ok; issues, ok = pending[next] {
+				delete(pending, next)
+				next++
+				<-tokens
+
+				for _, i := range issues {
+					if !yield(i) {
+						return
+					}
 				}
 			}
 		}
+	}
+}
+
+type numbered[T any] struct {
+	seq   int
+	value T
+}
 
-		return issues
+// produce sends the commits to work in order, taking a token for each.
+func produce(
+	cmts commits.Commits,
+	tokens chan<- struct{},
+	work chan<- numbered[*commits.Commit],
+	done <-chan struct{},
+) {
+	defer close(work)
+
+	seq := 0
+
+	for c := range cmts {
+		select {
+		case tokens <- struct{}{}:
+		case <-done:
+			return
+		}
+
+		select {
+		case work <- numbered[*commits.Commit]{seq, c}:
+		case <-done:
+			return
+		}
+
+		seq++
 	}
 }
 
-// Printed prints the issues to the writer.
-func Printed(w io.Writer, sep string, issues Issues) Issues {
-	return func() []Issue {
-		iss := issues()
+// evaluate runs the filters on the work with this many workers and closes
+// the results when all work is done.
+func evaluate(
+	workers int,
+	filters []Filter,
+	work <-chan numbered[*commits.Commit],
+	results chan<- numbered[[]Issue],
+) {
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
 
-		for idx := range iss {
-			i := iss[idx]
+			for w := range work {
+				results <- numbered[[]Issue]{w.seq, evaluated(filters, w.value)}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+}
+
+func evaluated(filters []Filter, c *commits.Commit) []Issue {
+	issues := make([]Issue, 0)
 
+	for _, f := range filters {
+		if issue := f(c); issue.Desc != "" {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// Printed prints the issues to the writer as they are identified.
+func Printed(w io.Writer, sep string, issues Issues) Issues {
+	return func(yield func(Issue) bool) {
+		for i := range issues {
 			_, err := color.New(color.Bold).Fprintf(w, "%s: ", i.Commit.ShortID())
 			if err != nil {
 				panic(err)
@@ -65,8 +191,21 @@ func Printed(w io.Writer, sep string, issues Issues) Issues {
 			if err != nil {
 				panic(err)
 			}
+
+			if !yield(i) {
+				return
+			}
 		}
+	}
+}
 
-		return iss
+// Count iterates through the issues and returns how many there are.
+func Count(issues Issues) int {
+	n := 0
+
+	for range issues {
+		n++
 	}
+
+	return n
 }
@@ -16,7 +16,11 @@ package issues_test
 
 import (
 	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -29,7 +33,7 @@ func TestCollected(t *testing.T) {
 		{Hash: "123"},
 		{Hash: "456"},
 	}
-	isus := issues.Collected(
+	isus := slices.Collect(iter.Seq[issues.Issue](issues.Collected(
 		[]issues.Filter{
 			func(c *commits.Commit) issues.Issue {
 				var issue issues.Issue
@@ -44,10 +48,8 @@ func TestCollected(t *testing.T) {
 				return issue
 			},
 		},
-		func() []*commits.Commit {
-			return append(expected, &commits.Commit{Hash: "789"})
-		},
-	)()
+		commits.Commits(slices.Values(append(expected, &commits.Commit{Hash: "789"}))),
+	)))
 
 	assert.Len(t,
 		isus,
@@ -89,16 +91,42 @@ func TestPrinted(t *testing.T) {
 
 	writer := &mockWriter{}
 
-	issues.Printed(
+	count := issues.Count(issues.Printed(
 		writer, sep,
-		func() []issues.Issue {
-			return isus
-		},
-	)()
+		issues.Issues(slices.Values(isus)),
+	))
 
 	assert.Equal(t,
 		expected, writer.msg,
 		"issues.Printed() must join Commit.ShortID() and the Issue.Desc with the separator")
+	assert.Equal(t, len(isus), count,
+		"issues.Printed() must pass on the issues it prints")
+}
+
+func TestPrintedStreams(t *testing.T) {
+	writer := &mockWriter{}
+	printed := issues.Printed(
+		writer, "\n",
+		issues.Collected(
+			[]issues.Filter{
+				func(c *commits.Commit) issues.Issue {
+					return issues.Issue{Desc: "test", Commit: *c}
+				},
+			},
+			func(yield func(*commits.Commit) bool) {
+				if !yield(&commits.Commit{Hash: "18045269d8d2fd8f53d01883c6c7b548d0b9e3ae"}) {
+					return
+				}
+
+				assert.NotEmpty(t, writer.msg,
+					"issues.Printed() must print issues before the next commit is fetched")
+
+				yield(&commits.Commit{Hash: "4be918ff8bfc91de77a1462707a8d2eb30956f93"})
+			},
+		),
+	)
+
+	assert.Equal(t, 2, issues.Count(printed))
 }
 
 type mockWriter struct {
@@ -109,3 +137,59 @@ func (m *mockWriter) Write(b []byte) (int, error) {
 	m.msg += string(b)
 	return len(b), nil
 }
+
+func TestCollectedConcurrently(t *testing.T) {
+	cmits := make([]*commits.Commit, 0)
+
+	for i := range 100 {
+		cmits = append(cmits, &commits.Commit{Hash: fmt.Sprintf("%040d", i)})
+	}
+
+	filters := []issues.Filter{
+		func(c *commits.Commit) issues.Issue {
+			time.Sleep(time.Duration(rand.IntN(1000)) * time.Microsecond)
+
+			return issues.Issue{Desc: "first", Commit: *c}
+		},
+		func(c *commits.Commit) issues.Issue {
+			return issues.Issue{Desc: "second", Commit: *c}
+		},
+	}
+
+	assert.Equal(t,
+		slices.Collect(iter.Seq[issues.Issue](
+			issues.Collected(filters, commits.Commits(slices.Values(cmits))),
+		)),
+		slices.Collect(iter.Seq[issues.Issue](
+			issues.CollectedConcurrently(8, filters, commits.Commits(slices.Values(cmits))),
+		)),
+		"issues.CollectedConcurrently() must return the issues in the same order as issues.Collected()")
+}
+
+func TestCollectedConcurrentlyStops(t *testing.T) {
+	fetched := 0
+	infinite := func(yield func(*commits.Commit) bool) {
+		for {
+			fetched++
+
+			if !yield(&commits.Commit{Hash: "18045269d8d2fd8f53d01883c6c7b548d0b9e3ae"}) {
+				return
+			}
+		}
+	}
+
+	for range issues.CollectedConcurrently(
+		4,
+		[]issues.Filter{
+			func(c *commits.Commit) issues.Issue {
+				return issues.Issue{Desc: "test", Commit: *c}
+			},
+		},
+		infinite,
+	) {
+		break
+	}
+
+	assert.LessOrEqual(t, fetched, 4*4+2,
+		"issues.CollectedConcurrently() must not fetch commits far ahead of those consumed")
+}
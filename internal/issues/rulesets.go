@@ -0,0 +1,38 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"fmt"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+// Scoped makes the filter part of the named rule set, which only applies to
+// the commits changing files selected by the pathspec. Issues found by the
+// filter are labeled with the rule set's name.
+func Scoped(name string, paths commits.Pathspec, filter Filter) Filter {
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if c.Touches(paths) {
+			if issue = filter(c); issue.Desc != "" {
+				issue.Desc = fmt.Sprintf("[%s] %s", name, issue.Desc)
+			}
+		}
+
+		return issue
+	}
+}
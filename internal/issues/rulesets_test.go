@@ -0,0 +1,59 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+func TestScoped(t *testing.T) {
+	filter := issues.Scoped(
+		"infra/**",
+		commits.PathsOf([]string{"infra/**"}),
+		issues.OfRequiredTrailers([]string{"Change-Ticket"}),
+	)
+
+	assert.Equal(t,
+		"[infra/**] missing trailers [Change-Ticket]",
+		filter(changing("infra/main.tf", "README.md")).Desc,
+		"filter.Scoped() must label the issues found in commits changing selected files",
+	)
+	assert.Zero(t,
+		filter(changing("README.md")).Desc,
+		"filter.Scoped() must not apply to commits not changing selected files",
+	)
+	assert.Zero(t,
+		filter(&commits.Commit{Message: "not in a repo"}).Desc,
+		"filter.Scoped() must not apply to commits without changes",
+	)
+}
+
+func changing(paths ...string) *commits.Commit {
+	changes := make([]*commits.Change, 0, len(paths))
+
+	for _, p := range paths {
+		changes = append(changes, &commits.Change{Path: p})
+	}
+
+	return &commits.Commit{
+		Message: "subject",
+		Changes: func() []*commits.Change { return changes },
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+// Scope is a Conventional Commits scope and the files it covers.
+// See https://www.conventionalcommits.org.
+type Scope struct {
+	Name  string
+	Paths commits.Pathspec
+}
+
+// OfScopes checks that the scope in a commit's subject, as in
+// "feat(billing): ...", covers at least one of the files changed by the
+// commit, and that none of the other files belong to a different scope.
+// Several scopes may be given separated by commas. Commits without a scope
+// or without changes are not checked.
+func OfScopes(scopes []*Scope) Filter {
+	conventional := regexp.MustCompile(`^\w+\(([^()]+)\)!?:`)
+
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		match := conventional.FindStringSubmatch(c.Subject())
+		if match == nil || c.Changes == nil {
+			return issue
+		}
+
+		claimed, unknown := scopesNamed(scopes, strings.Split(match[1], ","))
+
+		switch {
+		case unknown != "":
+			issue = Issue{Desc: fmt.Sprintf("scope [%s] covers no paths", unknown), Commit: *c}
+		case !touchesAny(c, claimed):
+			issue = Issue{
+				Desc:   fmt.Sprintf("scope [%s] covers none of the changed files", match[1]),
+				Commit: *c,
+			}
+		default:
+			if path, required := unclaimed(scopes, claimed, c); required != nil {
+				issue = Issue{
+					Desc:   fmt.Sprintf("changed file [%s] requires scope [%s]", path, required.Name),
+					Commit: *c,
+				}
+			}
+		}
+
+		return issue
+	}
+}
+
+// scopesNamed returns the scopes with these names, or the first name that is
+// not a known scope.
+func scopesNamed(scopes []*Scope, names []string) (named []*Scope, unknown string) {
+	named = make([]*Scope, 0, len(names))
+
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		found := false
+
+		for _, s := range scopes {
+			if s.Name == n {
+				named = append(named, s)
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, n
+		}
+	}
+
+	return named, ""
+}
+
+func touchesAny(c *commits.Commit, scopes []*Scope) bool {
+	for _, s := range scopes {
+		if c.Touches(s.Paths) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unclaimed returns the first changed file that isn't covered by the claimed
+// scopes but is by another scope, along with that scope.
+func unclaimed(scopes, claimed []*Scope, c *commits.Commit) (string, *Scope) {
+	for _, change := range c.Changes() {
+		if covering(claimed, change.Path) != nil {
+			continue
+		}
+
+		if required := covering(scopes, change.Path); required != nil {
+			return change.Path, required
+		}
+	}
+
+	return "", nil
+}
+
+func covering(scopes []*Scope, path string) *Scope {
+	for _, s := range scopes {
+		if s.Paths(path) {
+			return s
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+func TestOfScopes(t *testing.T) {
+	filter := issues.OfScopes([]*issues.Scope{
+		{Name: "billing", Paths: commits.PathsOf([]string{"services/billing/**"})},
+		{Name: "users", Paths: commits.PathsOf([]string{"services/users/**"})},
+	})
+
+	for _, test := range []struct {
+		subject  string
+		paths    []string
+		expected string
+	}{
+		{"feat(billing): add invoices", []string{"services/billing/invoice.go", "README.md"}, ""},
+		{
+			"feat(billing,users)!: share accounts",
+			[]string{"services/billing/a.go", "services/users/a.go"},
+			"",
+		},
+		{"fix: no scope", []string{"services/users/a.go"}, ""},
+		{
+			"fix(users): wrong scope",
+			[]string{"services/billing/a.go"},
+			"scope [users] covers none of the changed files",
+		},
+		{
+			"fix(billing): too wide",
+			[]string{"services/billing/a.go", "services/users/a.go"},
+			"changed file [services/users/a.go] requires scope [users]",
+		},
+		{
+			"fix(payments): unknown",
+			[]string{"services/billing/a.go"},
+			"scope [payments] covers no paths",
+		},
+	} {
+		c := changing(test.paths...)
+		c.Message = test.subject
+
+		assert.Equal(t, test.expected, filter(c).Desc,
+			"filter.OfScopes() must check the scope covers the changed files: %s", test.subject,
+		)
+	}
+}
+
+func TestOfScopesWithoutChanges(t *testing.T) {
+	assert.Zero(t,
+		issues.OfScopes([]*issues.Scope{})(&commits.Commit{Message: "feat(billing): not in a repo"}),
+		"filter.OfScopes() must not check commits without changes",
+	)
+}
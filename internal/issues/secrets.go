@@ -0,0 +1,226 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+// redactedPrefix is how many characters of a secret are kept when reporting
+// it, enough to find it without leaking it further.
+const redactedPrefix = 4
+
+type credential struct {
+	kind  string
+	regex *regexp.Regexp
+}
+
+// OfSecrets checks that a commit's subject, body and trailers don't contain
+// credentials in well-known formats (AWS access keys, GitHub tokens, private
+// keys, JWTs and passwords in URLs) or strings whose Shannon entropy, in bits
+// per character, is at least the given entropy. An entropy of zero or less
+// disables that check. Secrets matching any of the allowlist regexes are
+// ignored. Secrets are redacted in the issues.
+// It fails if any of the allowlist regexes is invalid.
+func OfSecrets(entropy float64, allowlist []string) (Filter, error) {
+	allowed := make([]*regexp.Regexp, 0, len(allowlist))
+	errs := make([]error, 0)
+
+	for _, a := range allowlist {
+		if a == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(a)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid secrets allowlist regex [%s]: %w", a, err))
+
+			continue
+		}
+
+		allowed = append(allowed, re)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	credentials := []*credential{
+		{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		{
+			"GitHub token",
+			regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`),
+		},
+		{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----`)},
+		{"JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]+`)},
+		{"password in URL", regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^:/\s@]+:[^@\s]+@`)},
+	}
+	candidates := regexp.MustCompile(`[A-Za-z0-9+/_=-]{20,}`)
+
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		found := make([]string, 0)
+
+		for _, part := range partsOf(c) {
+			for _, s := range secretsIn(part.text, credentials, candidates, entropy) {
+				if !matchesAny(allowed, s.value) {
+					found = append(found, fmt.Sprintf("%s [%s] in %s", s.kind, redacted(s.value), part.name))
+				}
+			}
+		}
+
+		if len(found) > 0 {
+			issue = Issue{
+				Desc:   fmt.Sprintf("possible secrets: %s", strings.Join(found, ", ")),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}, nil
+}
+
+type part struct {
+	name string
+	text string
+}
+
+type secret struct {
+	kind  string
+	value string
+}
+
+// partsOf splits the commit's message into its subject, the rest of the
+// message without the trailers, and each line of the trailers. Whole trailer
+// lines are scanned since keys may be part of a secret, like URL schemes.
+func partsOf(c *commits.Commit) []*part {
+	msg := strings.TrimRight(c.Message, " \t\n")
+	subject, body, _ := strings.Cut(msg, "\n")
+	parts := []*part{{"subject", subject}}
+
+	if len(c.Trailers()) == 0 {
+		return append(parts, &part{"body", body})
+	}
+
+	last := strings.LastIndex(msg, "\n\n")
+	parts = append(parts, &part{"body", msg[min(len(subject)+1, last):last]})
+	name := ""
+
+	for _, line := range strings.Split(msg[last+2:], "\n") {
+		if strings.TrimLeft(line, " \t") == line {
+			key, _, _ := strings.Cut(line, ":")
+			name = fmt.Sprintf("trailer [%s]", key)
+		}
+
+		parts = append(parts, &part{name, line})
+	}
+
+	return parts
+}
+
+func secretsIn(
+	text string, credentials []*credential, candidates *regexp.Regexp, entropy float64,
+) []*secret {
+	secrets := make([]*secret, 0)
+
+	for _, cred := range credentials {
+		for _, m := range cred.regex.FindAllString(text, -1) {
+			secrets = append(secrets, &secret{cred.kind, m})
+		}
+	}
+
+	if entropy <= 0 {
+		return secrets
+	}
+
+	for _, m := range candidates.FindAllString(text, -1) {
+		if !known(secrets, m) && !isHex(m) && hasLettersAndDigits(m) && shannon(m) >= entropy {
+			secrets = append(secrets, &secret{"high-entropy string", m})
+		}
+	}
+
+	return secrets
+}
+
+// known tells whether s overlaps with any of the secrets already found.
+func known(secrets []*secret, s string) bool {
+	for _, k := range secrets {
+		if strings.Contains(k.value, s) || strings.Contains(s, k.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isHex spares commit hashes and UUIDs, which are often quoted in messages.
+func isHex(s string) bool {
+	for _, r := range strings.ReplaceAll(s, "-", "") {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasLettersAndDigits(s string) bool {
+	return strings.ContainsAny(s, "0123456789") &&
+		strings.ContainsAny(strings.ToLower(s), "abcdefghijklmnopqrstuvwxyz")
+}
+
+// shannon is the Shannon entropy of s in bits per character.
+func shannon(s string) float64 {
+	counts := make(map[rune]int)
+
+	for _, r := range s {
+		counts[r]++
+	}
+
+	entropy := 0.0
+	length := float64(len(s))
+
+	for _, n := range counts {
+		p := float64(n) / length
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
+
+func redacted(s string) string {
+	if len(s) <= redactedPrefix {
+		return strings.Repeat("*", len(s))
+	}
+
+	return s[:redactedPrefix] + strings.Repeat("*", 4)
+}
+
+func matchesAny(regexes []*regexp.Regexp, s string) bool {
+	for _, r := range regexes {
+		if r.MatchString(s) {
+			return true
+		}
+	}
+
+	return false
+}
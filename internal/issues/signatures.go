@@ -0,0 +1,288 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+const (
+	pgpHeader    = "-----BEGIN PGP SIGNATURE-----"
+	sshHeader    = "-----BEGIN SSH SIGNATURE-----"
+	sshFooter    = "-----END SSH SIGNATURE-----"
+	sshSigMagic  = "SSHSIG"
+	sshNamespace = "git"
+)
+
+var errUnknownKey = errors.New("no allowed signer for key")
+
+// AllowedSigner is an entry in an SSH allowed signers file.
+// See the ALLOWED SIGNERS section of ssh-keygen(1).
+type AllowedSigner struct {
+	Principals []string
+	Key        ssh.PublicKey
+}
+
+// OfSignature checks that a commit carries a valid PGP or SSH signature made
+// with a key belonging to the commit author's email. PGP signatures are
+// verified against the armored keyring and SSH signatures against the allowed
+// signers file; either reader may be nil if that kind of signature is not
+// accepted. Messages that aren't the repository's commits, like tags, patches
+// and --msg-file messages, aren't checked.
+// It fails if the keyring or allowed signers cannot be parsed.
+func OfSignature(keyring, allowedSigners io.Reader) (Filter, error) {
+	pgpKeys, pgpErr := pgpKeyring(keyring)
+	sshKeys, sshErr := sshAllowedSigners(allowedSigners)
+
+	if err := errors.Join(pgpErr, sshErr); err != nil {
+		return nil, err
+	}
+
+	return func(c *commits.Commit) Issue {
+		var (
+			issue  Issue
+			emails []string
+			err    error
+		)
+
+		switch {
+		case c.Tag != "" || !isHash(c.Hash):
+			return issue
+		case c.Signature == "":
+			return Issue{Desc: "commit is not signed", Commit: *c}
+		case strings.HasPrefix(c.Signature, pgpHeader):
+			emails, err = verifyPGP(pgpKeys, c)
+		case strings.HasPrefix(c.Signature, sshHeader):
+			emails, err = verifySSH(sshKeys, c)
+		default:
+			err = errors.New("unsupported signature format")
+		}
+
+		switch {
+		case err != nil:
+			issue = Issue{Desc: fmt.Sprintf("bad signature: %s", err), Commit: *c}
+		case c.Author == nil || !containsEmail(emails, c.Author.Email):
+			issue = Issue{Desc: "signing key does not belong to the author", Commit: *c}
+		}
+
+		return issue
+	}, nil
+}
+
+func pgpKeyring(reader io.Reader) (openpgp.EntityList, error) {
+	if reader == nil {
+		return openpgp.EntityList{}, nil
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PGP keyring: %w", err)
+	}
+
+	return keyring, nil
+}
+
+func verifyPGP(keyring openpgp.EntityList, c *commits.Commit) ([]string, error) {
+	signer, err := openpgp.CheckArmoredDetachedSignature(
+		keyring, strings.NewReader(c.Payload), strings.NewReader(c.Signature), nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]string, 0, len(signer.Identities))
+
+	for _, id := range signer.Identities {
+		if id.UserId != nil {
+			emails = append(emails, id.UserId.Email)
+		}
+	}
+
+	return emails, nil
+}
+
+// sshAllowedSigners parses lines of the form:
+//
+//	principals [options] keytype base64-key [comment]
+func sshAllowedSigners(reader io.Reader) ([]*AllowedSigner, error) {
+	signers := make([]*AllowedSigner, 0)
+
+	if reader == nil {
+		return signers, nil
+	}
+
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		principals, rest, _ := strings.Cut(line, " ")
+
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed signer [%s]: %w", line, err)
+		}
+
+		signers = append(signers, &AllowedSigner{
+			Principals: strings.Split(principals, ","),
+			Key:        key,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return signers, nil
+}
+
+// sshSignature is the SSHSIG blob format.
+// See https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+func verifySSH(signers []*AllowedSigner, c *commits.Commit) ([]string, error) {
+	sig, err := parseSSHSignature(c.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := sshHash(sig.HashAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	digest.Write([]byte(c.Payload))
+
+	signed := append([]byte(sshSigMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          digest.Sum(nil),
+	})...)
+
+	signature := &ssh.Signature{}
+	if err = ssh.Unmarshal(sig.Signature, signature); err != nil {
+		return nil, err
+	}
+
+	if err = key.Verify(signed, signature); err != nil {
+		return nil, err
+	}
+
+	return principalsOf(signers, key)
+}
+
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	body := strings.TrimSpace(armored)
+	body = strings.TrimPrefix(body, sshHeader)
+	body = strings.TrimSuffix(body, sshFooter)
+
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(blob, []byte(sshSigMagic)) {
+		return nil, errors.New("missing SSHSIG preamble")
+	}
+
+	sig := &sshSignature{}
+	if err = ssh.Unmarshal(blob[len(sshSigMagic):], sig); err != nil {
+		return nil, err
+	}
+
+	if sig.Namespace != sshNamespace {
+		return nil, fmt.Errorf("unexpected namespace [%s]", sig.Namespace)
+	}
+
+	return sig, nil
+}
+
+func sshHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm [%s]", algorithm)
+	}
+}
+
+func principalsOf(signers []*AllowedSigner, key ssh.PublicKey) ([]string, error) {
+	principals := make([]string, 0)
+
+	for _, s := range signers {
+		if bytes.Equal(s.Key.Marshal(), key.Marshal()) {
+			principals = append(principals, s.Principals...)
+		}
+	}
+
+	if len(principals) == 0 {
+		return nil, errUnknownKey
+	}
+
+	return principals, nil
+}
+
+// containsEmail reports whether the email matches any of the patterns, which
+// may contain the wildcards supported by allowed signers files.
+func containsEmail(patterns []string, email string) bool {
+	for _, p := range patterns {
+		matched, err := path.Match(strings.ToLower(p), strings.ToLower(email))
+		if err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+const (
+	payload    = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ntest subject\n"
+	commitHash = "18045269d8d2fd8f53d5d2ef4c1d8f4d1b1c8f61"
+)
+
+func TestOfSignatureUnsigned(t *testing.T) {
+	assert.Equal(t,
+		"commit is not signed",
+		must(t)(issues.OfSignature(nil, nil))(signed("john@test.com", "", "")).Desc,
+		"filter.OfSignature() must match unsigned commits",
+	)
+}
+
+func TestOfSignatureNotCommits(t *testing.T) {
+	filter := must(t)(issues.OfSignature(nil, nil))
+
+	for _, c := range []*commits.Commit{
+		{Hash: "/tmp/msg", Author: author("john@test.com")},
+		{Hash: "series.mbox:1", Author: author("john@test.com")},
+		{Hash: commitHash, Tag: "v1.0.0", Author: author("john@test.com")},
+		{Branch: "main"},
+	} {
+		assert.Zero(t, filter(c),
+			"filter.OfSignature() must not check messages that aren't commits: %+v", c)
+	}
+}
+
+func TestOfSignaturePGP(t *testing.T) {
+	entity, err := openpgp.NewEntity("John", "", "john@test.com", nil)
+	require.NoError(t, err)
+
+	keyring := &bytes.Buffer{}
+	w, err := armor.Encode(keyring, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	sig := &strings.Builder{}
+	require.NoError(t, openpgp.ArmoredDetachSign(sig, entity, strings.NewReader(payload), nil))
+
+	filter := must(t)(issues.OfSignature(bytes.NewReader(keyring.Bytes()), nil))
+
+	assert.Zero(t,
+		filter(signed("john@test.com", sig.String(), payload)),
+		"filter.OfSignature() must not match commits signed by the author's PGP key",
+	)
+	assert.Equal(t,
+		"signing key does not belong to the author",
+		filter(signed("jane@test.com", sig.String(), payload)).Desc,
+		"filter.OfSignature() must match commits signed by another person's PGP key",
+	)
+	assert.Contains(t,
+		filter(signed("john@test.com", sig.String(), "tampered")).Desc,
+		"bad signature",
+		"filter.OfSignature() must match commits with invalid PGP signatures",
+	)
+}
+
+func TestOfSignatureSSH(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	sig := sshSign(t, signer, payload)
+	allowed := "john@test.com,*@admins.org " + string(ssh.MarshalAuthorizedKey(sshPub))
+	filter := must(t)(issues.OfSignature(nil, strings.NewReader(allowed)))
+
+	assert.Zero(t,
+		filter(signed("john@test.com", sig, payload)),
+		"filter.OfSignature() must not match commits signed by the author's SSH key",
+	)
+	assert.Zero(t,
+		filter(signed("root@admins.org", sig, payload)),
+		"filter.OfSignature() must support wildcard principals",
+	)
+	assert.Equal(t,
+		"signing key does not belong to the author",
+		filter(signed("jane@test.com", sig, payload)).Desc,
+		"filter.OfSignature() must match commits signed by another person's SSH key",
+	)
+	assert.Contains(t,
+		filter(signed("john@test.com", sig, "tampered")).Desc,
+		"bad signature",
+		"filter.OfSignature() must match commits with invalid SSH signatures",
+	)
+	assert.Contains(t,
+		must(t)(issues.OfSignature(nil, nil))(
+			signed("john@test.com", sig, payload),
+		).Desc,
+		"bad signature",
+		"filter.OfSignature() must match commits signed by SSH keys that are not allowed",
+	)
+}
+
+func TestOfSignatureInvalidKeys(t *testing.T) {
+	_, err := issues.OfSignature(
+		strings.NewReader("not a keyring"), strings.NewReader("john@test.com not-a-key"),
+	)
+	assert.Error(t, err,
+		"filter.OfSignature() must fail if the keyring or allowed signers are invalid")
+}
+
+// signed returns a commit by the email's author with the signature of the
+// data.
+func signed(email, signature, data string) *commits.Commit {
+	return &commits.Commit{
+		Hash:      commitHash,
+		Author:    author(email),
+		Signature: signature,
+		Payload:   data,
+	}
+}
+
+func author(email string) *commits.Author {
+	return &commits.Author{Name: "Test", Email: email}
+}
+
+// sshSign creates an armored SSHSIG signature like `ssh-keygen -Y sign -n git` does.
+func sshSign(t *testing.T, signer ssh.Signer, msg string) string {
+	digest := sha512.Sum512([]byte(msg))
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{"git", "", "sha512", digest[:]})...)
+
+	sig, err := signer.Sign(rand.Reader, signed)
+	require.NoError(t, err)
+
+	blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, signer.PublicKey().Marshal(), "git", "", "sha512", ssh.Marshal(sig)})...)
+
+	return "-----BEGIN SSH SIGNATURE-----\n" +
+		base64.StdEncoding.EncodeToString(blob) +
+		"\n-----END SSH SIGNATURE-----\n"
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+)
+
+// OfTagNameRegex tests the name of annotated tags with the regex, like a
+// semantic version. Commits are not tags and pass.
+// It fails if the regex is invalid.
+func OfTagNameRegex(regex string) (Filter, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag name regex [%s]: %w", regex, err)
+	}
+
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if c.Tag != "" && !re.MatchString(c.Tag) {
+			issue = Issue{
+				Desc:   fmt.Sprintf("tag name does not match regex [%s]", regex),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}, nil
+}
+
+// OfTaggerRegex tests the identity of the tagger of annotated tags, as in
+// "Name <email>", with the regex. Commits are not tags and pass.
+// It fails if the regex is invalid.
+func OfTaggerRegex(regex string) (Filter, error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tagger regex [%s]: %w", regex, err)
+	}
+
+	return func(c *commits.Commit) Issue {
+		var issue Issue
+
+		if c.Tag == "" || c.Author == nil {
+			return issue
+		}
+
+		if tagger := fmt.Sprintf("%s <%s>", c.Author.Name, c.Author.Email); !re.MatchString(tagger) {
+			issue = Issue{
+				Desc:   fmt.Sprintf("tagger [%s] does not match regex [%s]", tagger, regex),
+				Commit: *c,
+			}
+		}
+
+		return issue
+	}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package issues_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/issues"
+)
+
+const semver = `^v\d+\.\d+\.\d+$`
+
+func TestOfTagNameRegex(t *testing.T) {
+	filter := must(t)(issues.OfTagNameRegex(semver))
+
+	assert.Empty(t, filter(&commits.Commit{Tag: "v1.2.3"}).Desc,
+		"issues.OfTagNameRegex() must pass tags whose names match the regex")
+	assert.Equal(t, "tag name does not match regex ["+semver+"]",
+		filter(&commits.Commit{Tag: "release-1"}).Desc,
+		"issues.OfTagNameRegex() must report tags whose names don't match the regex")
+	assert.Empty(t, filter(&commits.Commit{Hash: "18045269d8d2fd8f53d5d2ef4c1d8f4d1b1c8f61"}).Desc,
+		"issues.OfTagNameRegex() must pass commits")
+}
+
+func TestOfTaggerRegex(t *testing.T) {
+	filter := must(t)(issues.OfTaggerRegex(`@example\.com>$`))
+	tag := func(email string) *commits.Commit {
+		return &commits.Commit{
+			Tag: "v1.0.0", Author: &commits.Author{Name: "Release Bot", Email: email},
+		}
+	}
+
+	assert.Empty(t, filter(tag("bot@example.com")).Desc,
+		"issues.OfTaggerRegex() must pass tags whose tagger matches the regex")
+	assert.Equal(t, `tagger [Release Bot <bot@evil.com>] does not match regex [@example\.com>$]`,
+		filter(tag("bot@evil.com")).Desc,
+		"issues.OfTaggerRegex() must report tags whose tagger doesn't match the regex")
+	assert.Empty(t, filter(&commits.Commit{Author: &commits.Author{Email: "bot@evil.com"}}).Desc,
+		"issues.OfTaggerRegex() must pass commits")
+}
+
+func TestOfTagRegexInvalid(t *testing.T) {
+	_, err := issues.OfTagNameRegex("[")
+	assert.Error(t, err, "issues.OfTagNameRegex() must fail on invalid regexes")
+
+	_, err = issues.OfTaggerRegex("[")
+	assert.Error(t, err, "issues.OfTaggerRegex() must fail on invalid regexes")
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 George Aristy
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"errors"
+	"os"
+
+	"github.com/go-git/go-billy/v6/helper/chroot"
+	"github.com/go-git/go-billy/v6/helper/mount"
+	"github.com/go-git/go-billy/v6/memfs"
+	"github.com/go-git/go-billy/v6/osfs"
+	git "github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/cache"
+	"github.com/go-git/go-git/v6/storage/filesystem"
+)
+
+// Quarantined makes the objects received by a push visible in the repository
+// while its pre-receive hooks run. Git keeps them in the quarantine directory
+// named by the GIT_QUARANTINE_PATH environment variable until the hooks accept
+// the push; see git-receive-pack(1). The repository is returned as is when not
+// run from a hook.
+func Quarantined(repository Repo) Repo {
+	return func() *git.Repository {
+		r := repository()
+		dir := os.Getenv("GIT_QUARANTINE_PATH")
+		storage, ok := r.Storer.(*filesystem.Storage)
+
+		if dir == "" || !ok {
+			return r
+		}
+
+		// the quarantine is an objects directory, so it's mounted as one
+		incoming := filesystem.NewStorage(
+			chroot.New(mount.New(memfs.New(), "objects", osfs.New(dir)), "/"),
+			cache.NewObjectLRUDefault(),
+		)
+
+		quarantined, err := git.Open(&quarantine{Storage: storage, incoming: incoming}, nil)
+		if err != nil {
+			panic(err)
+		}
+
+		return quarantined
+	}
+}
+
+// quarantine looks objects up in the incoming storage before the repository's.
+type quarantine struct {
+	*filesystem.Storage
+	incoming *filesystem.Storage
+}
+
+func (q *quarantine) EncodedObject(
+	t plumbing.ObjectType, h plumbing.Hash,
+) (plumbing.EncodedObject, error) {
+	obj, err := q.incoming.EncodedObject(t, h)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return q.Storage.EncodedObject(t, h)
+	}
+
+	return obj, err
+}
+
+func (q *quarantine) HasEncodedObject(h plumbing.Hash) error {
+	if err := q.incoming.HasEncodedObject(h); err == nil {
+		return nil
+	}
+
+	return q.Storage.HasEncodedObject(h)
+}
+
+func (q *quarantine) EncodedObjectSize(h plumbing.Hash) (int64, error) {
+	size, err := q.incoming.EncodedObjectSize(h)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return q.Storage.EncodedObjectSize(h)
+	}
+
+	return size, err
+}
@@ -16,17 +16,29 @@
 package repo
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-billy/v6"
+	"github.com/go-git/go-billy/v6/osfs"
 	git "github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/config"
+	xconfig "github.com/go-git/go-git/v6/x/plugin/config"
 )
 
 // Repo is an initialized git repository.
 type Repo func() *git.Repository
 
-// Filesystem is a pre-existing git repository on the filesystem
-// with directory as root.
+// Filesystem is a pre-existing git repository on the filesystem, found
+// the way git finds it from directory: with $GIT_DIR and $GIT_WORK_TREE if
+// set, or else in directory or the closest of its parents that is either a
+// worktree, whose .git may be a file pointing to the git directory as in
+// linked worktrees and submodules, or a bare repository.
 func Filesystem(directory string) Repo {
 	return func() *git.Repository {
-		repo, err := git.PlainOpen(directory)
+		repo, err := discovered(directory)
 		if err != nil {
 			panic(err)
 		}
@@ -34,3 +46,146 @@ func Filesystem(directory string) Repo {
 		return repo
 	}
 }
+
+// Found tells whether Filesystem finds a repository from directory.
+func Found(directory string) bool {
+	_, err := discovered(directory)
+
+	return err == nil
+}
+
+func discovered(directory string) (*git.Repository, error) {
+	if gitDir := os.Getenv("GIT_DIR"); gitDir != "" {
+		return fromEnvironment(directory, gitDir)
+	}
+
+	dir, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	for !isRepo(dir) {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("%w: %s or any of its parents", git.ErrRepositoryNotExists, directory)
+		}
+
+		dir = parent
+	}
+
+	return git.PlainOpen(dir)
+}
+
+// isRepo tells whether dir has a .git, or is itself a git directory.
+func isRepo(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, git.GitDirName)); err == nil {
+		return true
+	}
+
+	head, err := os.Stat(filepath.Join(dir, "HEAD"))
+	if err != nil || head.IsDir() {
+		return false
+	}
+
+	objects, err := os.Stat(filepath.Join(dir, "objects"))
+
+	return err == nil && objects.IsDir()
+}
+
+// fromEnvironment opens the git directory, relative to directory like
+// `git -C`, with the worktree in $GIT_WORK_TREE or core.worktree. Like git,
+// directory is the worktree if neither is set, unless the repository is bare.
+func fromEnvironment(directory, gitDir string) (*git.Repository, error) {
+	r, err := git.PlainOpen(relativeTo(directory, gitDir))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := r.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	worktree := os.Getenv("GIT_WORK_TREE")
+
+	switch {
+	case worktree != "":
+		worktree = relativeTo(directory, worktree)
+	case cfg.Core.Worktree != "":
+		worktree = relativeTo(relativeTo(directory, gitDir), cfg.Core.Worktree)
+	case cfg.Core.IsBare:
+		return r, nil
+	default:
+		worktree = directory
+	}
+
+	return git.Open(r.Storer, osfs.New(worktree, osfs.WithBoundOS()))
+}
+
+func relativeTo(directory, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(directory, path)
+}
+
+// GitDir returns the path to the repository's git directory, or "" if the
+// repository is not stored on the filesystem.
+func GitDir(repository Repo) string {
+	if storage, ok := repository().Storer.(interface{ Filesystem() billy.Filesystem }); ok {
+		return storage.Filesystem().Root()
+	}
+
+	return ""
+}
+
+// Worktree returns the path to the repository's worktree, or "" if the
+// repository is bare.
+func Worktree(repository Repo) string {
+	wt, err := repository().Worktree()
+	if errors.Is(err, git.ErrIsBareRepository) {
+		return ""
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	return wt.Filesystem.Root()
+}
+
+// Option returns the value of the option in the repository's configuration
+// or, if unset there, in the user's or the system's, like `git config` does.
+// Options in subsections are not supported.
+func Option(repository Repo, section, key string) string {
+	local, err := repository().Config()
+	if err != nil {
+		panic(err)
+	}
+
+	configs := []*config.Config{local}
+	source := xconfig.NewAuto()
+
+	for _, scope := range []config.Scope{config.GlobalScope, config.SystemScope} {
+		storer, err := source.Load(scope)
+		if err != nil {
+			panic(err)
+		}
+
+		cfg, err := storer.Config()
+		if err != nil {
+			panic(err)
+		}
+
+		configs = append(configs, cfg)
+	}
+
+	for _, cfg := range configs {
+		if cfg.Raw.HasSection(section) && cfg.Raw.Section(section).HasOption(key) {
+			return cfg.Raw.Section(section).Option(key)
+		}
+	}
+
+	return ""
+}
@@ -49,6 +49,114 @@ func TestFilesystem(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGitDir(t *testing.T) {
+	_, path := tmpGitRepo(t, "commit1")
+
+	assert.Equal(t, filepath.Join(path, ".git"), repo.GitDir(repo.Filesystem(path)),
+		"repo.GitDir() must return the path to the .git directory")
+}
+
+func TestFilesystemSubdirectory(t *testing.T) {
+	_, path := tmpGitRepo(t, "commit1")
+	subdir := filepath.Join(path, "a", "b")
+	require.NoError(t, os.MkdirAll(subdir, 0o700))
+
+	assert.Equal(t, filepath.Join(path, ".git"), repo.GitDir(repo.Filesystem(subdir)),
+		"repo.Filesystem() must find the repository enclosing the directory")
+}
+
+func TestWorktree(t *testing.T) {
+	_, path := tmpGitRepo(t, "commit1")
+	subdir := filepath.Join(path, "a", "b")
+	require.NoError(t, os.MkdirAll(subdir, 0o700))
+
+	assert.Equal(t, path, repo.Worktree(repo.Filesystem(subdir)),
+		"repo.Worktree() must return the root of the worktree enclosing the directory")
+
+	bare := t.TempDir()
+	_, err := git.PlainInit(bare, true)
+	require.NoError(t, err)
+
+	assert.Empty(t, repo.Worktree(repo.Filesystem(bare)),
+		"repo.Worktree() must return nothing for bare repositories")
+}
+
+func TestFilesystemLinkedWorktree(t *testing.T) {
+	r, path := tmpGitRepo(t, "commit1")
+	head, err := r.Head()
+	require.NoError(t, err)
+
+	worktree := t.TempDir()
+	gitDir := filepath.Join(path, ".git", "worktrees", "linked")
+	require.NoError(t, os.MkdirAll(gitDir, 0o700))
+
+	for file, contents := range map[string]string{
+		filepath.Join(gitDir, "HEAD"):      head.Hash().String() + "\n",
+		filepath.Join(gitDir, "commondir"): "../..\n",
+		filepath.Join(gitDir, "gitdir"):    filepath.Join(worktree, ".git") + "\n",
+		filepath.Join(worktree, ".git"):    "gitdir: " + gitDir + "\n",
+	} {
+		require.NoError(t, os.WriteFile(file, []byte(contents), 0o600))
+	}
+
+	linked := repo.Filesystem(worktree)
+	linkedHead, err := linked().Head()
+	require.NoError(t, err)
+
+	assert.Equal(t, head.Hash(), linkedHead.Hash(),
+		"repo.Filesystem() must follow the .git file of linked worktrees")
+	assert.Equal(t, gitDir, repo.GitDir(linked),
+		"repo.GitDir() must return the linked worktree's own git directory")
+}
+
+func TestFilesystemBare(t *testing.T) {
+	path := t.TempDir()
+	_, err := git.PlainInit(path, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, path, repo.GitDir(repo.Filesystem(filepath.Join(path, "refs", "heads"))),
+		"repo.Filesystem() must open bare repositories")
+}
+
+func TestFilesystemEnvironment(t *testing.T) {
+	_, path := tmpGitRepo(t, "commit1")
+	worktree := t.TempDir()
+
+	t.Setenv("GIT_DIR", filepath.Join(path, ".git"))
+	t.Setenv("GIT_WORK_TREE", worktree)
+
+	r := repo.Filesystem(t.TempDir())
+	wt, err := r().Worktree()
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(path, ".git"), repo.GitDir(r),
+		"repo.Filesystem() must honor $GIT_DIR")
+	assert.Equal(t, worktree, wt.Filesystem.Root(),
+		"repo.Filesystem() must honor $GIT_WORK_TREE")
+}
+
+func TestOption(t *testing.T) {
+	global := filepath.Join(t.TempDir(), "gitconfig")
+	require.NoError(t, os.WriteFile(
+		global, []byte("[core]\n\tcommentChar = %\n\thooksPath = /global/hooks\n"), 0o600,
+	))
+	t.Setenv("GIT_CONFIG_GLOBAL", global)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	r, path := tmpGitRepo(t, "commit1")
+	local, err := r.Config()
+	require.NoError(t, err)
+	local.Raw.Section("core").SetOption("hooksPath", "local/hooks")
+	require.NoError(t, r.SetConfig(local))
+
+	assert.Equal(t, "%", repo.Option(repo.Filesystem(path), "core", "commentChar"),
+		"repo.Option() must fall back to the user's configuration")
+	assert.Equal(t, "local/hooks", repo.Option(repo.Filesystem(path), "core", "hooksPath"),
+		"repo.Option() must prefer the repository's configuration")
+	assert.Empty(t, repo.Option(repo.Filesystem(path), "core", "unknown"),
+		"repo.Option() must return nothing for unset options")
+}
+
 func tmpGitRepo(t *testing.T, msgs ...string) (r *git.Repository, folder string) {
 	folder = t.TempDir()
 
@@ -15,81 +15,358 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	"math"
 	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/llorllale/go-gitlint/internal/commits"
+	"github.com/llorllale/go-gitlint/internal/hooks"
 	"github.com/llorllale/go-gitlint/internal/issues"
 	"github.com/llorllale/go-gitlint/internal/repo"
 )
 
 func main() {
 	var (
-		path             = kingpin.Flag("path", `Path to the git repo (default: ".").`).Default(".").String()                                                                                         //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		subjectRegex     = kingpin.Flag("subject-regex", `Commit subject line must conform to this regular expression (default: ".*").`).Default(".*").String()                                       //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		subjectMaxLength = kingpin.Flag("subject-maxlen", "Max length for commit subject line (default: math.MaxInt32 - 1).").Default(strconv.Itoa(math.MaxInt32 - 1)).Int()                          //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		subjectMinLength = kingpin.Flag("subject-minlen", "Min length for commit subject line (default: 0).").Default("0").Int()                                                                      //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		bodyRegex        = kingpin.Flag("body-regex", `Commit message body must conform to this regular expression (default: ".*").`).Default(".*").String()                                          //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		bodyMaxLength    = kingpin.Flag("body-maxlen", `Max length for commit body (default: math.MaxInt32 - 1)`).Default(strconv.Itoa(math.MaxInt32 - 1)).Int()                                      //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		since            = kingpin.Flag("since", `A date in "yyyy-MM-dd" format starting from which commits will be analyzed (default: "1970-01-01").`).Default("1970-01-01").String()                //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		msgFile          = kingpin.Flag("msg-file", `Only analyze the commit message found in this file (default: "").`).Default("").String()                                                         //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		maxParents       = kingpin.Flag("max-parents", `Max number of parents a commit can have in order to be analyzed (default: 1). Useful for excluding merge commits.`).Default("1").Int()        //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		authorNames      = kingpin.Flag("excl-author-names", "Don't lint commits with authors whose names match these comma-separated regular expressions (default: '$a').").Default("$a").String()   //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
-		authorEmails     = kingpin.Flag("excl-author-emails", "Don't lint commits with authors whose emails match these comma-separated regular expressions (default: '$a').").Default("$a").String() //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		path             = kingpin.Flag("path", `Path to the git repo or any directory in it (default: ".").`).Default(".").String()                                                                                             //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		subjectRegex     = kingpin.Flag("subject-regex", `Commit subject line must conform to this regular expression (default: ".*").`).Default(".*").String()                                                                  //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		subjectMaxLength = kingpin.Flag("subject-maxlen", "Max length for commit subject line (default: math.MaxInt32 - 1).").Default(strconv.Itoa(math.MaxInt32 - 1)).Int()                                                     //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		subjectMinLength = kingpin.Flag("subject-minlen", "Min length for commit subject line (default: 0).").Default("0").Int()                                                                                                 //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		bodyRegex        = kingpin.Flag("body-regex", `Commit message body must conform to this regular expression (default: ".*").`).Default(".*").String()                                                                     //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		bodyMaxLength    = kingpin.Flag("body-maxlen", `Max length for commit body (default: math.MaxInt32 - 1)`).Default(strconv.Itoa(math.MaxInt32 - 1)).Int()                                                                 //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		requireTrailers  = kingpin.Flag("require-trailers", `Commit message must end with trailers having these comma-separated keys, like "Signed-off-by" (default: "").`).Default("").String()                                 //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		maxFiles         = kingpin.Flag("max-files", "Max number of files a commit can change (default: -1, no limit).").Default("-1").Int()                                                                                     //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		largeFiles       = kingpin.Flag("large-change-files", "Commits changing more files than this must have a body of --large-change-body length (default: -1, never).").Default("-1").Int()                                  //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		largeLines       = kingpin.Flag("large-change-lines", "Commits changing more lines than this must have a body of --large-change-body length (default: -1, never).").Default("-1").Int()                                  //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		largeBody        = kingpin.Flag("large-change-body", "Min length for the body of commits over --large-change-files or --large-change-lines (default: 1).").Default("1").Int()                                            //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		maxFileSize      = kingpin.Flag("max-file-size", `Max size of the files a commit adds or modifies, like "512KB" or "10MB" (default: 0, no limit).`).Default("0").Bytes()                                                 //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		forbidBinaries   = kingpin.Flag("forbid-binaries", "Report commits adding or modifying binary files, except those allowed by --allowed-binaries (default: false).").Default("false").Bool()                              //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		allowedBinaries  = kingpin.Flag("allowed-binaries", `Comma-separated .gitignore-style patterns of the binary files allowed with --forbid-binaries (default: "").`).Default("").String()                                  //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		forbiddenFiles   = kingpin.Flag("forbidden-files", `Report commits adding or modifying files matching these comma-separated .gitignore-style patterns, like "*.pem,.env" (default: "").`).Default("").String()           //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		checkSecrets     = kingpin.Flag("check-secrets", "Report commit messages containing credentials like AWS keys, GitHub tokens, private keys, JWTs or random-looking strings (default: false).").Default("false").Bool()   //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		secretsEntropy   = kingpin.Flag("secrets-entropy", "Min Shannon entropy, in bits per character, of random-looking strings with --check-secrets; 0 disables it (default: 4.5).").Default("4.5").Float64()                 //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		secretsAllowlist = kingpin.Flag("secrets-allowlist", `Ignore secrets matching these comma-separated regular expressions with --check-secrets (default: "").`).Default("").String()                                       //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		since            = kingpin.Flag("since", `Only analyze commits authored at or after this date: "yyyy-MM-dd", RFC 3339, or relative like "2.weeks.ago" or "90d" (default: "1970-01-01").`).Default("1970-01-01").String() //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		until            = kingpin.Flag("until", `Only analyze commits authored at or before this date, in the same formats as --since (default: "").`).Default("").String()                                                     //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		maxCount         = kingpin.Flag("max-count", "Max number of commits to walk back from HEAD, counting those excluded by other flags (default: -1, no limit).").Default("-1").Int()                                        //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		msgFiles         = kingpin.Flag("msg-file", `Only analyze the commit messages in these files or glob patterns, or in stdin for "-". Repeatable.`).Strings()                                                              //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		cleanup          = kingpin.Flag("cleanup", `How to clean up --msg-file like git: strip, whitespace, scissors or verbatim (default: commit.cleanup or strip).`).Default("").String()                                      //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		patches          = kingpin.Flag("patch", `Only analyze the patches in these mbox or format-patch files or glob patterns, or in stdin for "-". Repeatable.`).Strings()                                                    //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		tags             = kingpin.Flag("tags", `Only analyze the annotated tags whose names match this glob pattern, like "v*", or "*" for all (default: "").`).Default("").String()                                            //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		tagsRange        = kingpin.Flag("tags-range", `Only analyze the annotated tags on commits in this range, like "v1.0..HEAD" (default: "").`).Default("").String()                                                         //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		tagRegex         = kingpin.Flag("tag-regex", `Names of annotated tags must conform to this regular expression, like "^v\d+\.\d+\.\d+$" (default: "").`).Default("").String()                                             //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		taggerRegex      = kingpin.Flag("tagger-regex", `Taggers of annotated tags, as "Name <email>", must conform to this regular expression (default: "").`).Default("").String()                                             //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		branches         = kingpin.Flag("branches", `With the branch command, lint the local and remote branches matching this glob pattern (default: "", the current branch).`).Default("").String()                            //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		branchRegex      = kingpin.Flag("branch-regex", `Branch names must conform to this regular expression (default: ".*").`).Default(".*").String()                                                                          //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		branchMaxLength  = kingpin.Flag("branch-maxlen", "Max length for branch names (default: math.MaxInt32 - 1).").Default(strconv.Itoa(math.MaxInt32 - 1)).Int()                                                             //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		preReceive       = kingpin.Flag("pre-receive", "Run as a pre-receive hook, linting the commits pushed to each ref as read from stdin (default: false).").Default("false").Bool()                                         //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		maxParents       = kingpin.Flag("max-parents", `Max number of parents a commit can have in order to be analyzed (default: 1). Useful for excluding merge commits.`).Default("1").Int()                                   //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		authorNames      = kingpin.Flag("excl-author-names", "Don't lint commits with authors whose names match these comma-separated regular expressions (default: '$a').").Default("$a").String()                              //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		authorEmails     = kingpin.Flag("excl-author-emails", "Don't lint commits with authors whose emails match these comma-separated regular expressions (default: '$a').").Default("$a").String()                            //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		pathFilter       = kingpin.Flag("path-filter", `Only lint commits changing files that match these comma-separated .gitignore-style patterns, compared to their first parent (default: "").`).Default("").String()        //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		checkDates       = kingpin.Flag("check-dates", "Report commits dated in the future, before the root commit, before their parents, or authored after being committed (default: false).").Default("false").Bool()          //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		futureTolerance  = kingpin.Flag("future-tolerance", "How far in the future commit dates may be with --check-dates (default: 24h).").Default("24h").Duration()                                                            //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		authorDateSkew   = kingpin.Flag("author-date-skew", "How far after the commit date the author date may be with --check-dates (default: 1h).").Default("1h").Duration()                                                   //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		requireSignature = kingpin.Flag("require-signature", "Report commits that are unsigned or not signed with a key belonging to their author (default: false).").Default("false").Bool()                                    //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		pgpKeyring       = kingpin.Flag("pgp-keyring", `Armored PGP keyring to verify signatures with --require-signature (default: "").`).Default("").String()                                                                  //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		allowedSigners   = kingpin.Flag("allowed-signers", `SSH allowed signers file to verify signatures with --require-signature (default: "").`).Default("").String()                                                         //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		jobs             = kingpin.Flag("jobs", "Number of commits to lint concurrently; output order is unaffected (default: number of CPUs).").Default(strconv.Itoa(runtime.NumCPU())).Int()                                   //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		useCache         = kingpin.Flag("cache", "Cache results per commit in the repo's git directory, reusing them until the rules' configuration changes (default: false).").Default("false").Bool()                          //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		requireMailmap   = kingpin.Flag("require-mailmap", "Report commits whose author or committer identity is not in the repo's .mailmap (default: false).").Default("false").Bool()                                          //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		rulesets         = kingpin.Flag("ruleset", `Apply a rule only to commits changing files matching comma-separated patterns, as "<patterns>:<flag>=<value>". Repeatable.`).Strings()                                       //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		scopes           = kingpin.Flag("scope", `Conventional Commits scope and the comma-separated patterns of the files it covers, as "<scope>:<patterns>". Repeatable.`).Strings()                                           //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		disallowIgnore   = kingpin.Flag("disallow-ignore", `Comma-separated rules that commits can't ignore with Gitlint-Ignore trailers, like "check-secrets" (default: "").`).Default("").String()                             //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		reportIgnored    = kingpin.Flag("report-ignored", "Print the issues that commits ignore with Gitlint-Ignore trailers (default: false).").Default("false").Bool()                                                         //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		baselineFile     = kingpin.Flag("baseline", `Ignore the issues recorded in this file by the baseline create command, and create it there (default: "").`).Default("").String()                                           //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		reportStale      = kingpin.Flag("report-stale-baseline", "Report the entries of the --baseline whose issues were not found again (default: false).").Default("false").Bool()                                             //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		_                = kingpin.Command("lint", "Lint the commits (default).").Default()                                                                                                                                      //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		installHook      = kingpin.Command("install-hook", "Install gitlint as the repo's commit-msg hook, keeping any existing hook.")                                                                                          //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		uninstallHook    = kingpin.Command("uninstall-hook", "Remove the commit-msg hook installed by gitlint, restoring any hook it kept.")                                                                                     //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		branch           = kingpin.Command("branch", "Lint the names of branches instead of commits.")                                                                                                                           //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		baselineCmd      = kingpin.Command("baseline", "Manage the baseline of known issues.")                                                                                                                                   //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
+		baselineCreate   = baselineCmd.Command("create", "Record the current issues in the --baseline file (default: .gitlint-baseline).")                                                                                       //nolint:lll,gochecknoglobals // https://github.com/llorllale/go-gitlint/issues/23
 	)
 
-	configure()
-	os.Exit(
-		len(
-			issues.Printed(
-				os.Stdout, "\n",
-				issues.Collected(
-					[]issues.Filter{
-						issues.OfSubjectRegex(*subjectRegex),
-						issues.OfSubjectMaxLength(*subjectMaxLength),
-						issues.OfSubjectMinLength(*subjectMinLength),
-						issues.OfBodyRegex(*bodyRegex),
-						issues.OfBodyMaxLength(*bodyMaxLength),
-					},
-					try(
-						len(*msgFile) > 0,
-						func() commits.Commits {
-							file, err := os.Open(*msgFile)
-							if err != nil {
-								panic(err)
-							}
-							return commits.MsgIn(file)
-						},
-						func() commits.Commits {
-							return commits.NotAuthoredByNames(
-								strings.Split(*authorNames, ","),
-								commits.NotAuthoredByEmails(
-									strings.Split(*authorEmails, ","),
-									commits.WithMaxParents(
-										*maxParents,
-										commits.Since(
-											*since,
-											commits.In(
-												repo.Filesystem(*path),
-											),
-										),
-									),
-								),
-							)
-						},
+	command := configure()
+
+	switch command {
+	case installHook.FullCommand():
+		executable, err := os.Executable()
+		kingpin.FatalIfError(err, "cannot locate gitlint")
+		kingpin.FatalIfError(
+			hooks.Install(hooks.Dir(repo.Filesystem(*path)), executable), "cannot install hook",
+		)
+
+		return
+	case uninstallHook.FullCommand():
+		kingpin.FatalIfError(
+			hooks.Uninstall(hooks.Dir(repo.Filesystem(*path))), "cannot uninstall hook",
+		)
+
+		return
+	}
+
+	var errs problems
+
+	repository := repo.Quarantined(repo.Filesystem(*path))
+
+	now := time.Now()
+	start := errs.date("since", *since, now)
+	end := errs.date("until", *until, now)
+	named := []*rule{
+		{"subject-regex", errs.filter(issues.OfSubjectRegex(*subjectRegex))},
+		{"subject-maxlen", issues.OfSubjectMaxLength(*subjectMaxLength)},
+		{"subject-minlen", issues.OfSubjectMinLength(*subjectMinLength)},
+		{"body-regex", errs.filter(issues.OfBodyRegex(*bodyRegex))},
+		{"body-maxlen", issues.OfBodyMaxLength(*bodyMaxLength)},
+		{"require-trailers", issues.OfRequiredTrailers(strings.Split(*requireTrailers, ","))},
+		{"max-files", optional(*maxFiles >= 0, issues.OfMaxFiles(*maxFiles))},
+		{"large-change-body", optional(
+			*largeFiles >= 0 || *largeLines >= 0,
+			issues.OfBodyForLargeChanges(*largeFiles, *largeLines, *largeBody),
+		)},
+		{"max-file-size", optional(*maxFileSize > 0, issues.OfLargeFiles(int64(*maxFileSize)))},
+		{"forbid-binaries", optional(
+			*forbidBinaries,
+			issues.OfBinaryFiles(commits.PathsOf(strings.Split(*allowedBinaries, ","))),
+		)},
+		{"forbidden-files", optional(
+			*forbiddenFiles != "",
+			issues.OfForbiddenFiles(commits.PathsOf(strings.Split(*forbiddenFiles, ","))),
+		)},
+		{"check-secrets", optional(
+			*checkSecrets,
+			errs.filter(issues.OfSecrets(*secretsEntropy, strings.Split(*secretsAllowlist, ","))),
+		)},
+		{"require-mailmap", optional(*requireMailmap, issues.OfCanonicalIdentity())},
+		{"check-dates", optional(
+			*checkDates && repo.Found(*path),
+			issues.OfDatesBeforeRoots(commits.Roots(repository)),
+		)},
+		{"check-dates", optional(*checkDates, issues.OfAuthorDateSkew(*authorDateSkew))},
+		{"check-dates", optional(*checkDates, issues.OfDatesBeforeParents())},
+		{"tag-regex", optional(*tagRegex != "", errs.filter(issues.OfTagNameRegex(*tagRegex)))},
+		{"tagger-regex", optional(*taggerRegex != "", errs.filter(issues.OfTaggerRegex(*taggerRegex)))},
+		{"require-signature", optional(
+			*requireSignature,
+			errs.filter(issues.OfSignature(errs.open(*pgpKeyring), errs.open(*allowedSigners))),
+		)},
+	}
+
+	for _, r := range *rulesets {
+		named = append(named, errs.ruleset(r))
+	}
+
+	if len(*scopes) > 0 {
+		named = append(named, &rule{"scope", issues.OfScopes(errs.scopes(*scopes))})
+	}
+
+	if command == branch.FullCommand() {
+		named = []*rule{
+			{"branch-regex", errs.filter(issues.OfBranchNameRegex(*branchRegex))},
+			{"branch-maxlen", issues.OfBranchNameMaxLength(*branchMaxLength)},
+		}
+	}
+
+	rules := make([]issues.Filter, 0, len(named)+1)
+
+	for _, r := range named {
+		rules = append(rules, r.filter)
+	}
+
+	var cache *issues.Cache
+
+	if *useCache && len(*msgFiles) == 0 && len(*patches) == 0 && command != branch.FullCommand() {
+		cache = issues.CacheIn(
+			filepath.Join(repo.GitDir(repo.Filesystem(*path)), "gitlint"),
+			cacheKey(
+				commits.MailmapOf(repo.Filesystem(*path)),
+				*pgpKeyring, *allowedSigners,
+			),
+		)
+		rules = issues.Cached(cache, rules)
+	}
+
+	// the cache only records descriptions so rules are named after it
+	for i, r := range named {
+		rules[i] = issues.Named(r.name, rules[i])
+	}
+
+	// results depend on the current time so they must never be cached
+	rules = append(rules, issues.Named("check-dates",
+		optional(*checkDates, issues.OfFutureDates(now, *futureTolerance))))
+	selected := func(cmts commits.Commits) commits.Commits {
+		return commits.Touching(
+			strings.Split(*pathFilter, ","),
+			errs.commits(commits.NotAuthoredByNames(
+				strings.Split(*authorNames, ","),
+				errs.commits(commits.NotAuthoredByEmails(
+					strings.Split(*authorEmails, ","),
+					commits.WithMaxParents(
+						*maxParents,
+						commits.Since(
+							start,
+							commits.Until(
+								end,
+								commits.Mailmapped(commits.MailmapOf(repository), cmts),
+							),
+						),
 					),
-				),
-			)(),
-		),
-	)
+				)),
+			)),
+		)
+	}
+	creatingBaseline := command == baselineCreate.FullCommand()
+
+	var baseline *issues.Baseline
+
+	if !creatingBaseline {
+		baseline = errs.baseline(*baselineFile)
+	}
+
+	pipeline := func(cmts commits.Commits) issues.Issues {
+		found := issues.Ignored(
+			strings.Split(*disallowIgnore, ","),
+			optionalWriter(*reportIgnored, os.Stdout),
+			issues.CollectedConcurrently(*jobs, rules, cmts),
+		)
+
+		if creatingBaseline {
+			return found
+		}
+
+		return issues.Printed(os.Stdout, "\n", issues.Baselined(baseline, found))
+	}
+
+	var pipelines []*refPipeline
+
+	switch {
+	case command == branch.FullCommand():
+		pipelines = append(pipelines,
+			&refPipeline{"", pipeline(commits.Branches(repository, *branches))})
+	case *preReceive:
+		received := commits.Received(repository)
+
+		for _, u := range errs.updates(os.Stdin) {
+			pipelines = append(pipelines, &refPipeline{u.Ref, pipeline(selected(received(u)))})
+		}
+	case *tags != "" || *tagsRange != "":
+		pipelines = append(pipelines, &refPipeline{"", pipeline(commits.Mailmapped(
+			commits.MailmapOf(repository),
+			errs.commits(commits.Tags(repository, *tags, *tagsRange)),
+		))})
+	case len(*patches) > 0:
+		pipelines = append(pipelines,
+			&refPipeline{"", pipeline(errs.commits(commits.PatchesIn(os.Stdin, *patches)))})
+	case len(*msgFiles) > 0:
+		mode, comment := cleanupOf(*path, repository, *cleanup)
+		pipelines = append(pipelines, &refPipeline{"", pipeline(errs.commits(commits.CleanedUp(
+			mode,
+			comment,
+			errs.commits(commits.MsgsIn(os.Stdin, *msgFiles)),
+		)))})
+	default:
+		pipelines = append(pipelines, &refPipeline{"", pipeline(selected(commits.Walked(
+			repository,
+			commits.AtMost(*maxCount),
+			commits.CommittedBefore(start),
+		)))})
+	}
+
+	errs.check()
+
+	if creatingBaseline {
+		kingpin.FatalIfError(createBaseline(*baselineFile, pipelines), "cannot create baseline")
+		saveCache(cache)
+
+		return
+	}
+
+	found := 0
+
+	for _, p := range pipelines {
+		n := issues.Count(p.issues)
+		if n > 0 && p.ref != "" {
+			fmt.Printf("%s: rejected, %d issues found\n", p.ref, n)
+		}
+
+		found += n
+	}
+
+	if *reportStale && baseline != nil {
+		for _, e := range baseline.Stale() {
+			fmt.Printf("stale baseline entry [%s]\n", e)
+			found++
+		}
+	}
+
+	saveCache(cache)
+
+	// the status is truncated to a byte, so a hook rejects with 1 lest a
+	// multiple of 256 issues lets a push through
+	if *preReceive && found > 0 {
+		found = 1
+	}
+
+	os.Exit(found)
 }
 
-func configure() {
-	const file = ".gitlint"
+// createBaseline records the issues found by the pipelines in the file, or
+// in .gitlint-baseline if no file is given.
+func createBaseline(file string, pipelines []*refPipeline) error {
+	if file == "" {
+		file = ".gitlint-baseline"
+	}
+
+	all := func(yield func(issues.Issue) bool) {
+		for _, p := range pipelines {
+			for i := range p.issues {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+
+	n, err := issues.WriteBaseline(&b, all)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(file, []byte(b.String()), 0o644); err != nil { //nolint:gosec // meant to be committed
+		return err
+	}
+
+	fmt.Printf("recorded %d issues in %s\n", n, file)
 
+	return nil
+}
+
+func saveCache(cache *issues.Cache) {
+	if cache != nil {
+		if err := cache.Save(); err != nil {
+			kingpin.Errorf("cannot save cache: %s", err)
+		}
+	}
+}
+
+// configure parses the command line and the config file and returns the
+// selected command.
+func configure() string {
+	file := configFile(".")
 	args := os.Args[1:]
 
 	if _, err := os.Stat(file); err == nil {
@@ -101,19 +378,233 @@ func configure() {
 		args = append(args, config...)
 	}
 
-	if _, err := kingpin.CommandLine.Parse(unique(args)); err != nil {
-		panic(err)
+	command, err := kingpin.CommandLine.Parse(
+		unique(args, "--ruleset", "--scope", "--msg-file", "--patch"),
+	)
+	kingpin.FatalIfError(err, "")
+
+	return command
+}
+
+// configFile returns the .gitlint file at the root of the worktree enclosing
+// the directory, so that the same configuration applies wherever gitlint is
+// run from, or else the one in the directory.
+func configFile(directory string) string {
+	const file = ".gitlint"
+
+	if !repo.Found(directory) {
+		return filepath.Join(directory, file)
+	}
+
+	if root := repo.Worktree(repo.Filesystem(directory)); root != "" {
+		if _, err := os.Stat(filepath.Join(root, file)); err == nil {
+			return filepath.Join(root, file)
+		}
 	}
+
+	return filepath.Join(directory, file)
 }
 
-func unique(args []string) []string {
+// problems are the configuration errors found while assembling the pipeline.
+// They are all reported at once, before any commit is read.
+type problems []error
+
+func (p *problems) filter(f issues.Filter, err error) issues.Filter {
+	*p = append(*p, err)
+
+	return f
+}
+
+func (p *problems) commits(c commits.Commits, err error) commits.Commits {
+	*p = append(*p, err)
+
+	return c
+}
+
+// date parses the flag's date expression. An empty expression yields the
+// zero time.
+func (p *problems) date(flag, expr string, now time.Time) time.Time {
+	if expr == "" {
+		return time.Time{}
+	}
+
+	t, err := commits.ParseDate(expr, now)
+	if err != nil {
+		*p = append(*p, fmt.Errorf("--%s: %w", flag, err))
+	}
+
+	return t
+}
+
+// ruleset parses a --ruleset of the form "<patterns>:<flag>=<value>". The
+// rule set is named after its patterns, and its rule after the flag.
+func (p *problems) ruleset(spec string) *rule {
+	patterns, setting, found := strings.Cut(spec, ":")
+	flag, value, valid := strings.Cut(setting, "=")
+
+	if !found || !valid {
+		*p = append(*p, fmt.Errorf("--ruleset: expected <patterns>:<flag>=<value> but got [%s]", spec))
+
+		return &rule{}
+	}
+
+	return &rule{
+		flag,
+		issues.Scoped(patterns, commits.PathsOf(strings.Split(patterns, ",")), p.rule(flag, value)),
+	}
+}
+
+// rule returns the filter configured by the flag with this value.
+func (p *problems) rule(flag, value string) issues.Filter {
+	switch flag {
+	case "subject-regex":
+		return p.filter(issues.OfSubjectRegex(value))
+	case "subject-maxlen":
+		return issues.OfSubjectMaxLength(p.number(flag, value))
+	case "subject-minlen":
+		return issues.OfSubjectMinLength(p.number(flag, value))
+	case "body-regex":
+		return p.filter(issues.OfBodyRegex(value))
+	case "body-maxlen":
+		return issues.OfBodyMaxLength(p.number(flag, value))
+	case "require-trailers":
+		return issues.OfRequiredTrailers(strings.Split(value, ","))
+	}
+
+	*p = append(*p, fmt.Errorf("--ruleset: unsupported flag [%s]", flag))
+
+	return nil
+}
+
+// scopes parses each --scope of the form "<scope>:<patterns>".
+func (p *problems) scopes(specs []string) []*issues.Scope {
+	scopes := make([]*issues.Scope, 0, len(specs))
+
+	for _, s := range specs {
+		name, patterns, found := strings.Cut(s, ":")
+		if !found || name == "" {
+			*p = append(*p, fmt.Errorf("--scope: expected <scope>:<patterns> but got [%s]", s))
+
+			continue
+		}
+
+		scopes = append(scopes,
+			&issues.Scope{Name: name, Paths: commits.PathsOf(strings.Split(patterns, ","))})
+	}
+
+	return scopes
+}
+
+func (p *problems) number(flag, value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		*p = append(*p, fmt.Errorf("--ruleset: invalid %s [%s]: %w", flag, value, err))
+	}
+
+	return n
+}
+
+// updates parses the ref updates read from the reader.
+func (p *problems) updates(reader io.Reader) []*commits.Update {
+	updates, err := commits.UpdatesIn(reader)
+	if err != nil {
+		*p = append(*p, fmt.Errorf("--pre-receive: %w", err))
+	}
+
+	return updates
+}
+
+// baseline reads the baseline file. No file means no baseline.
+func (p *problems) baseline(path string) *issues.Baseline {
+	reader := p.open(path)
+	if reader == nil {
+		return nil
+	}
+
+	baseline, err := issues.BaselineIn(reader)
+	if err != nil {
+		*p = append(*p, fmt.Errorf("--baseline: %w", err))
+	}
+
+	return baseline
+}
+
+// open returns nil if the path is empty.
+func (p *problems) open(path string) io.Reader {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		*p = append(*p, err)
+
+		return nil
+	}
+
+	return file
+}
+
+// check exits with all the problems found, if any. Problems found more than
+// once, like when assembling a pipeline per pushed ref, are reported once.
+func (p *problems) check() {
+	unique := make([]error, 0, len(*p))
+
+	for _, err := range *p {
+		if err == nil {
+			continue
+		}
+
+		if !slices.ContainsFunc(unique, func(u error) bool { return u.Error() == err.Error() }) {
+			unique = append(unique, err)
+		}
+	}
+
+	kingpin.FatalIfError(errors.Join(unique...), "invalid configuration")
+}
+
+// cleanupOf returns the cleanup mode, defaulting to the repo's commit.cleanup,
+// and the repo's comment string. Message files may be linted outside of repos.
+func cleanupOf(directory string, repository repo.Repo, mode string) (string, string) {
+	if !repo.Found(directory) {
+		return mode, ""
+	}
+
+	if mode == "" {
+		mode = repo.Option(repository, "commit", "cleanup")
+	}
+
+	comment := repo.Option(repository, "core", "commentString")
+	if comment == "" {
+		comment = repo.Option(repository, "core", "commentChar")
+	}
+
+	return mode, comment
+}
+
+// rule is a filter named after the flag configuring it, which is how users
+// refer to it in Gitlint-Ignore trailers.
+type rule struct {
+	name   string
+	filter issues.Filter
+}
+
+// refPipeline lints the commits pushed to the ref, if any.
+type refPipeline struct {
+	ref    string
+	issues issues.Issues
+}
+
+// unique keeps the first occurrence of each flag so that the command line
+// overrides the config file. All occurrences of repeatable flags are kept.
+func unique(args []string, repeatable ...string) []string {
 	u := make([]string, 0)
 	flags := make([]string, 0)
 
 	for _, a := range args {
 		name := strings.Split(a, "=")[0]
 
-		if !contains(name, flags) {
+		if !contains(name, flags) || contains(name, repeatable) {
 			u = append(u, a)
 			flags = append(flags, name)
 		}
@@ -132,10 +623,56 @@ func contains(s string, strs []string) bool {
 	return false
 }
 
-func try(cond bool, actual, dflt func() commits.Commits) commits.Commits {
-	if cond {
-		return actual()
+// cacheKey identifies the effective rule configuration: the values of all the
+// flags that affect the rules, the contents of the files they name, the
+// mailmap and gitlint's version.
+func cacheKey(mailmap commits.Mailmap, files ...string) string {
+	// these only select which commits are linted and how, not the results
+	selection := []string{
+		"path", "since", "until", "max-count", "max-parents",
+		"excl-author-names", "excl-author-emails", "path-filter", "msg-file", "cleanup", "patch",
+		"pre-receive", "tags", "tags-range", "disallow-ignore", "report-ignored", "baseline",
+		"report-stale-baseline", "jobs", "cache",
+	}
+	h := sha256.New()
+
+	for _, f := range kingpin.CommandLine.Model().Flags {
+		if !contains(f.Name, selection) {
+			fmt.Fprintf(h, "--%s=%s\n", f.Name, f.Value)
+		}
 	}
 
-	return dflt()
+	for _, f := range files {
+		if b, err := os.ReadFile(f); err == nil { //nolint:gosec // files named in the configuration
+			h.Write(b)
+		}
+	}
+
+	for _, m := range mailmap() {
+		fmt.Fprintf(h, "%+v\n", *m)
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		h.Write([]byte(info.Main.Version))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func optionalWriter(enabled bool, w io.Writer) io.Writer {
+	if enabled {
+		return w
+	}
+
+	return nil
+}
+
+func optional(enabled bool, filter issues.Filter) issues.Filter {
+	if enabled {
+		return filter
+	}
+
+	return func(*commits.Commit) issues.Issue {
+		return issues.Issue{}
+	}
 }